@@ -1,50 +1,319 @@
 package main
 
 import (
-	"log"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/Ar1veeee/library-api/internal/broker"
 	"github.com/Ar1veeee/library-api/internal/config"
+	"github.com/Ar1veeee/library-api/internal/gqlapi"
+	"github.com/Ar1veeee/library-api/internal/grpcapi"
 	"github.com/Ar1veeee/library-api/internal/http/handler"
+	"github.com/Ar1veeee/library-api/internal/http/middleware"
 	"github.com/Ar1veeee/library-api/internal/http/routes"
+	"github.com/Ar1veeee/library-api/internal/logger"
+	"github.com/Ar1veeee/library-api/internal/metrics"
+	"github.com/Ar1veeee/library-api/internal/migration"
+	"github.com/Ar1veeee/library-api/internal/openapi"
+	"github.com/Ar1veeee/library-api/internal/outbox"
+	"github.com/Ar1veeee/library-api/internal/ratelimit"
 	"github.com/Ar1veeee/library-api/internal/repository"
+	"github.com/Ar1veeee/library-api/internal/server"
 	"github.com/Ar1veeee/library-api/internal/service"
+	"github.com/Ar1veeee/library-api/internal/stockstream"
+	"github.com/Ar1veeee/library-api/internal/tracing"
+	"github.com/Ar1veeee/library-api/internal/webhook"
+	"github.com/Ar1veeee/library-api/migrations"
 	"github.com/gorilla/mux"
 )
 
 func main() {
-	cfg := config.Load()
-
-	db, err := config.NewDatabase(cfg)
+	cfg, args, err := config.Load(os.Args[0], os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
 	if err != nil {
-		log.Fatalf("Failed to connect to database %v:", err)
+		// Logger belum ada karena level & format log juga berasal dari config.
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(2)
 	}
 
-	defer db.Close()
+	// slog.SetDefault juga mengarahkan package log standar ke handler yang sama,
+	// sehingga log dari library pihak ketiga tetap keluar sebagai JSON terstruktur.
+	log := logger.New(os.Stdout, cfg.LogLevel, cfg.LogFormat)
+	slog.SetDefault(log)
 
-	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping database %v:", err)
+	// Subcommand "migrate" dijalankan sebelum server agar schema bisa disiapkan dengan binary yang sama.
+	if len(args) > 0 && args[0] == "migrate" {
+		runMigrate(cfg, args[1:])
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		OTLPInsecure: cfg.TracingOTLPInsecure,
+		SampleRatio:  cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		log.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	db, err := config.NewDatabase(cfg)
+	if err != nil {
+		log.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	log.Info("database connected", "host", cfg.DBHost, "database", cfg.DBName, "environment", cfg.Environment)
+	if cfg.ClockOffset != 0 {
+		log.Warn("clock offset active, loan times are simulated", "offset", cfg.ClockOffset.String())
 	}
-	log.Println("✅ Database connected successfully")
 
 	bookRepo := repository.NewBookRepository(db)
 	memberRepo := repository.NewMemberRepository(db)
 	loanRepo := repository.NewLoanRepository(db)
+	holdRepo := repository.NewHoldRepository(db)
+	fineRepo := repository.NewFineRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	stockChangeRepo := repository.NewStockChangeRepository(db)
 
-	bookService := service.NewBookService(bookRepo)
-	memberService := service.NewMemberService(memberRepo, loanRepo)
-	loanService := service.NewLoanService(db, bookRepo, memberRepo, loanRepo)
+	metrics.RegisterDatabase(db, loanRepo, bookRepo, outboxRepo)
+
+	// Publisher membangunkan dispatcher di instance yang sama, sehingga event langsung dikirim tanpa menunggu poll.
+	dispatcher := webhook.NewDispatcher(webhookRepo, cfg.Clock(), webhook.Options{
+		PollInterval: cfg.WebhookPollInterval,
+		Timeout:      cfg.WebhookTimeout,
+		MaxAttempts:  cfg.WebhookMaxAttempts,
+		BackoffBase:  cfg.WebhookBackoffBase,
+		BackoffMax:   cfg.WebhookBackoffMax,
+
+		AllowPrivateTargets: cfg.WebhookAllowPrivateTargets,
+	}, log)
+	var wake func()
+	if cfg.WebhookDispatcherEnabled {
+		wake = dispatcher.Wake
+	}
+	publisher := webhook.NewPublisher(webhookRepo, cfg.Clock(), wake)
 
+	// LoanService hanya menulis event ke outbox; relay yang meneruskannya ke sink (webhook, log, broker).
+	relay := outbox.NewRelay(db, outboxRepo, cfg.Clock(), outboxSinks(cfg.OutboxSinks, publisher, broker.NewLocal(), log), cfg.OutboxPollInterval, cfg.OutboxMaxAttempts, log)
+
+	// Hub stream stok dibangunkan LoanService setelah commit; perubahan dari instance lain terbaca lewat polling.
+	stockHub := stockstream.NewHub(stockChangeRepo, cfg.StockStreamPollInterval, log)
+
+	bookService := service.NewBookService(db, bookRepo)
+	memberService := service.NewMemberService(memberRepo, loanRepo, cfg.Location())
+	loanService := service.NewLoanService(db, bookRepo, memberRepo, loanRepo, fineRepo, service.LoanPolicy{
+		MaxActiveLoans: cfg.LoanMaxActive,
+		LoanPeriodDays: cfg.LoanPeriodDays,
+	}, cfg.Location(), cfg.Clock(), outboxRepo, stockHub.Wake)
+	holdService := service.NewHoldService(holdRepo, cfg.Location())
+	webhookService := service.NewWebhookService(webhookRepo, cfg.Location(), cfg.Clock(), cfg.WebhookAllowPrivateTargets)
+
+	migrator, err := migration.New(db, migrations.Schema)
+	if err != nil {
+		log.Error("failed to load migrations", "error", err)
+		os.Exit(1)
+	}
+
+	readiness := server.NewReadiness()
+
+	healthHandler := handler.NewHealthHandler(db, migrator, readiness, cfg.ReadinessTimeout)
 	bookHandler := handler.NewBookHandler(bookService)
 	memberHandler := handler.NewMemberHandler(memberService)
 	loanHandler := handler.NewLoanHandler(loanService)
+	errorHandler := handler.NewErrorHandler()
+	graphqlHandler := handler.NewGraphQLHandler(gqlapi.NewServer(bookService, memberService, loanService, holdService))
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	stockStreamHandler := handler.NewStockStreamHandler(stockHub, bookService, cfg.StockStreamHeartbeatInterval)
+
+	apiDoc := openapi.Build()
+	apiSpec, err := json.Marshal(apiDoc)
+	if err != nil {
+		log.Error("failed to encode OpenAPI document", "error", err)
+		os.Exit(1)
+	}
+	docsHandler := handler.NewDocsHandler(apiSpec)
+
+	// CORS dipasang sebelum rate limit agar response 429 tetap membawa header CORS dan bisa dibaca browser.
+	apiMiddlewares := []mux.MiddlewareFunc{
+		middleware.CORS(middleware.CORSOptions{
+			AllowedOrigins:   cfg.CORSAllowedOrigins,
+			AllowedMethods:   cfg.CORSAllowedMethods,
+			AllowedHeaders:   cfg.CORSAllowedHeaders,
+			ExposedHeaders:   cfg.CORSExposedHeaders,
+			AllowCredentials: cfg.CORSAllowCredentials,
+			MaxAge:           cfg.CORSMaxAge,
+		}),
+	}
+	if cfg.RateLimitEnabled {
+		apiMiddlewares = append(apiMiddlewares, middleware.RateLimit(middleware.RateLimitOptions{
+			Read:  ratelimit.New(cfg.RateLimitPerMinute, cfg.RateLimitBurst),
+			Write: ratelimit.New(cfg.RateLimitWritePerMinute, cfg.RateLimitWriteBurst),
+			// Schema GraphQL hanya berisi query, sehingga POST /graphql memakai limit baca.
+			ReadOnlyPaths: []string{"/graphql"},
+			APIKeys:       cfg.RateLimitAPIKeys,
+			TrustProxy:    cfg.RateLimitTrustProxy,
+		}))
+	}
 
 	router := mux.NewRouter()
-	routes.RegisterRoutes(router, bookHandler, memberHandler, loanHandler)
+	if len(cfg.WebhookAPIKeys) == 0 {
+		log.Warn("webhook api keys not configured, /api/v1/webhooks is unauthenticated")
+	}
+	routes.RegisterRoutes(router, healthHandler, bookHandler, memberHandler, loanHandler, errorHandler, docsHandler, graphqlHandler,
+		stockStreamHandler, webhookHandler, middleware.RequireAPIKey(cfg.WebhookAPIKeys), apiMiddlewares...)
+
+	// Drift ditangkap oleh test (internal/openapi/drift_test.go); di sini hanya peringatan agar dokumen yang
+	// tidak lengkap tidak sampai menggagalkan deploy.
+	if err := openapi.CheckRoutes(router, apiDoc); err != nil {
+		log.Warn("OpenAPI document does not match registered routes", "error", err)
+	}
+
+	// MENGAPA signal.NotifyContext?
+	// - SIGTERM dikirim Docker/Kubernetes saat deploy; tanpa handling, proses langsung mati
+	//   dan transaksi borrow yang sedang berjalan terputus di tengah jalan
+	// - Context yang dibatalkan menjadi pemicu graceful shutdown di server.Run
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// Handler sinyal dilepas begitu sinyal pertama diterima, sehingga sinyal kedua (Ctrl+C lagi, SIGTERM susulan)
+	// kembali ke perilaku default dan langsung menghentikan proses jika graceful shutdown macet.
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	// Urutan middleware (luar → dalam): RequestID → SecurityHeaders → AccessLog → Metrics → Tracing → MatchRoute → router.
+	// RequestID paling luar agar access log, metric, tracing, dan semua handler membaca reqctx.Info yang sama.
+	var httpHandler http.Handler = middleware.MatchRoute(router)
+	httpHandler = middleware.Tracing(httpHandler)
+	httpHandler = middleware.Metrics(httpHandler)
+	httpHandler = middleware.AccessLog(log)(httpHandler)
+	httpHandler = middleware.SecurityHeaders(cfg.SecurityHSTSMaxAge)(httpHandler)
+	httpHandler = middleware.RequestID(httpHandler)
+
+	srv := server.New(cfg, httpHandler, readiness)
+	if cfg.GRPCEnabled {
+		if len(cfg.GRPCAPIKeys) == 0 {
+			log.Warn("grpc api keys not configured, gRPC API is unauthenticated")
+		}
+		grpcServer, grpcHealth := grpcapi.NewServer(grpcapi.Options{
+			BookService:   bookService,
+			MemberService: memberService,
+			LoanService:   loanService,
+			APIKeys:       cfg.GRPCAPIKeys,
+			Logger:        log,
+		})
+		srv.WithGRPC(grpcServer, grpcHealth, cfg.GRPCPort)
+	}
+
+	// Worker (relay outbox, webhook) berhenti saat sinyal diterima atau saat server berhenti karena error;
+	// pekerjaan yang sedang berjalan ditunggu sebelum pool DB ditutup.
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	if cfg.OutboxRelayEnabled {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			relay.Run(workerCtx)
+		}()
+	}
+	if cfg.WebhookDispatcherEnabled {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			dispatcher.Run(workerCtx)
+		}()
+		// Pemindaian overdue ikut ditunggu: transaksi MarkOverdueNotified + outbox yang sedang berjalan
+		// harus selesai sebelum pool DB ditutup.
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			runOverdueScan(workerCtx, loanService, cfg.WebhookOverdueScanInterval)
+		}()
+	}
+
+	// Stream SSE berjalan sampai HTTP server mulai shutdown (bukan saat sinyal diterima): selama drain delay
+	// client masih dilayani, lalu semua stream ditutup agar Shutdown tidak menunggu koneksi yang tidak pernah selesai.
+	streamCtx, stopStreams := context.WithCancel(context.Background())
+	srv.OnShutdown(stopStreams)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		stockHub.Run(streamCtx)
+	}()
+
+	runErr := srv.Run(ctx)
+	stopStreams()
+	stopWorkers()
+	workers.Wait()
+
+	// DB ditutup paling akhir: dispatcher dan relay mencatat hasil pengiriman yang sedang berjalan
+	// (MarkDelivered/MarkFailed) setelah ctx dibatalkan, sehingga pool harus tetap terbuka sampai mereka selesai.
+	if err := db.Close(); err != nil {
+		log.Warn("failed to close database pool", "error", err)
+	}
+
+	// Flush span yang tersisa setelah server berhenti; context baru karena ctx sinyal sudah dibatalkan.
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Warn("failed to flush traces", "error", err)
+	}
+	cancel()
+
+	if runErr != nil {
+		log.Error("server stopped with error", "error", runErr)
+		os.Exit(1)
+	}
+}
+
+// outboxSinks membangun daftar sink relay sesuai konfigurasi outbox.sinks (sudah divalidasi).
+func outboxSinks(names []string, webhooks *webhook.Publisher, b broker.Broker, log *slog.Logger) []outbox.Sink {
+	sinks := make([]outbox.Sink, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "webhook":
+			sinks = append(sinks, outbox.Sink{Name: name, Publisher: webhooks})
+		case "log":
+			sinks = append(sinks, outbox.Sink{Name: name, Publisher: outbox.NewLogSink(log)})
+		case "broker":
+			sinks = append(sinks, outbox.Sink{Name: name, Publisher: outbox.NewBrokerSink(b)})
+		}
+	}
+	return sinks
+}
+
+// runOverdueScan menulis event loan.overdue secara berkala sampai ctx dibatalkan.
+// Aman dijalankan di beberapa instance: setiap pinjaman diklaim sekali (lihat LoanService.NotifyOverdueLoans).
+func runOverdueScan(ctx context.Context, loanService *service.LoanService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		notified, err := loanService.NotifyOverdueLoans(ctx)
+		if err != nil && ctx.Err() == nil {
+			// Cause dicatat terpisah karena Error() milik APIError hanya berisi pesan untuk client.
+			slog.Error("failed to notify overdue loans", "error", err, "cause", errors.Unwrap(err))
+		} else if notified > 0 {
+			slog.Info("overdue loans notified", "count", notified)
+		}
 
-	addr := ":" + cfg.ServerPort
-	log.Printf("🚀 Server starting on %s", addr)
-	if err := http.ListenAndServe(addr, router); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
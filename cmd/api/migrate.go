@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Ar1veeee/library-api/internal/config"
+	"github.com/Ar1veeee/library-api/internal/migration"
+	"github.com/Ar1veeee/library-api/migrations"
+)
+
+const migrateUsage = `Usage: main migrate <command> [flags]
+
+Commands:
+  up              Apply all pending migrations
+  down [-steps N] Roll back the last N applied migrations (default 1)
+  status          Show applied and pending migrations
+  seed            Load optional fixture data (idempotent)
+`
+
+// runMigrate menangani subcommand "migrate" sehingga binary yang sama bisa dipakai
+// untuk menyiapkan schema sebelum server dijalankan.
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	db, err := config.NewDatabase(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := migration.New(db, migrations.Schema)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		if len(applied) == 0 {
+			log.Println("✅ Schema already up to date")
+		}
+		for _, m := range applied {
+			log.Printf("✅ Applied %06d_%s", m.Version, m.Name)
+		}
+
+	case "down":
+		flags := flag.NewFlagSet("down", flag.ExitOnError)
+		steps := flags.Int("steps", 1, "number of migrations to roll back")
+		_ = flags.Parse(args[1:])
+
+		reverted, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		for _, m := range reverted {
+			log.Printf("↩️  Reverted %06d_%s", m.Version, m.Name)
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, appliedAt := "pending", "-"
+			if s.Applied {
+				state = "applied"
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(tw, "%06d\t%s\t%s\t%s\n", s.Migration.Version, s.Migration.Name, state, appliedAt)
+		}
+		_ = tw.Flush()
+
+	case "seed":
+		fixtures, err := fs.Sub(migrations.Fixtures, "fixtures")
+		if err != nil {
+			log.Fatalf("Failed to load fixtures: %v", err)
+		}
+
+		names, err := migrator.Seed(ctx, fixtures)
+		if err != nil {
+			log.Fatalf("Seeding failed: %v", err)
+		}
+		for _, name := range names {
+			log.Printf("🌱 Loaded fixture %s", name)
+		}
+
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+)
+
+// catalogHeader adalah urutan kolom file CSV katalog.
+// Kolom stock hanya informatif saat export; saat import stok dihitung dari total_copies.
+var catalogHeader = []string{"id", "title", "author", "total_copies", "stock"}
+
+func (a *app) catalogExport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("catalog export", flag.ExitOnError)
+	format := flags.String("format", "csv", "output format: csv or json")
+	output := flags.String("o", "", "output file (default stdout)")
+	_ = flags.Parse(args)
+
+	entries, err := a.bookService.ExportCatalog(ctx)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(catalogHeader); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := cw.Write([]string{
+				strconv.Itoa(e.ID), e.Title, e.Author, strconv.Itoa(e.TotalCopies), strconv.Itoa(e.Stock),
+			}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+func (a *app) catalogImport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("catalog import", flag.ExitOnError)
+	format := flags.String("format", "", "input format: csv or json (default from file extension)")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("catalog import requires exactly one FILE argument")
+	}
+	path := flags.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var entries []dto.CatalogEntry
+	switch *format {
+	case "json":
+		if err := json.NewDecoder(f).Decode(&entries); err != nil {
+			return fmt.Errorf("invalid JSON catalog: %w", err)
+		}
+	case "csv":
+		entries, err = readCatalogCSV(f)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	result, err := a.bookService.ImportCatalog(ctx, entries)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported catalog: %d created, %d updated\n", result.Created, result.Updated)
+	return nil
+}
+
+// readCatalogCSV membaca CSV berdasarkan nama kolom di header, sehingga urutan kolom bebas
+// dan kolom id/stock boleh dihilangkan.
+func readCatalogCSV(r io.Reader) ([]dto.CatalogEntry, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"title", "author", "total_copies"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing column %q", required)
+		}
+	}
+
+	var entries []dto.CatalogEntry
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		entry := dto.CatalogEntry{
+			Title:  field("title"),
+			Author: field("author"),
+		}
+
+		if entry.TotalCopies, err = strconv.Atoi(field("total_copies")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid total_copies %q", line, field("total_copies"))
+		}
+		if id := field("id"); id != "" && id != "0" {
+			if entry.ID, err = strconv.Atoi(id); err != nil {
+				return nil, fmt.Errorf("line %d: invalid id %q", line, id)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+)
+
+func (a *app) memberCreate(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("member create", flag.ExitOnError)
+	name := flags.String("name", "", "member name")
+	email := flags.String("email", "", "member email")
+	language := flags.String("language", "", "preferred API message language (id or en)")
+	_ = flags.Parse(args)
+
+	member, err := a.memberService.CreateMember(ctx, dto.CreateMemberRequest{
+		Name:     *name,
+		Email:    *email,
+		Language: strings.ToLower(*language),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created member %d: %s <%s>\n", member.ID, member.Name, member.Email)
+	return nil
+}
+
+func (a *app) loanForceReturn(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("loan force-return", flag.ExitOnError)
+	loanID := flags.Int("id", 0, "loan ID to return")
+	_ = flags.Parse(args)
+
+	if *loanID <= 0 {
+		return fmt.Errorf("-id must be greater than 0")
+	}
+
+	if err := a.loanService.ForceReturn(ctx, *loanID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Loan %d returned\n", *loanID)
+	return nil
+}
+
+func (a *app) loanOverdue(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("loan overdue", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print as JSON")
+	_ = flags.Parse(args)
+
+	loans, err := a.loanService.ListOverdueLoans(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(loans)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOAN\tMEMBER\tEMAIL\tBOOK\tDUE AT\tDAYS OVERDUE")
+	for _, l := range loans {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\n", l.LoanID, l.MemberName, l.MemberEmail, l.BookTitle, l.DueAt, l.DaysOverdue)
+	}
+	return tw.Flush()
+}
+
+func (a *app) stockRecalculate(ctx context.Context) error {
+	corrections, err := a.bookService.RecalculateStock(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(corrections) == 0 {
+		fmt.Println("All stock levels are consistent")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BOOK\tTITLE\tCOPIES\tACTIVE LOANS\tOLD STOCK\tNEW STOCK")
+	for _, c := range corrections {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%d\n", c.BookID, c.Title, c.TotalCopies, c.ActiveLoans, c.OldStock, c.NewStock)
+	}
+	return tw.Flush()
+}
+
+// maintenanceJob adalah pekerjaan perawatan yang bisa dijalankan staf tanpa SQL manual.
+type maintenanceJob struct {
+	description string
+	run         func(ctx context.Context, a *app) error
+}
+
+var maintenanceJobs = map[string]maintenanceJob{
+	"recalculate-stock": {
+		description: "Recompute stock from total copies and active loans",
+		run: func(ctx context.Context, a *app) error {
+			return a.stockRecalculate(ctx)
+		},
+	},
+	"analyze-tables": {
+		description: "Refresh InnoDB index statistics for books, members and loans",
+		run: func(ctx context.Context, a *app) error {
+			if err := a.maintenanceRepo.AnalyzeTables(ctx); err != nil {
+				return err
+			}
+			fmt.Println("Table statistics refreshed")
+			return nil
+		},
+	},
+	"prune-outbox": {
+		description: "Delete outbox events published more than 7 days ago",
+		run: func(ctx context.Context, a *app) error {
+			deleted, err := a.maintenanceRepo.PruneOutbox(ctx, a.clock.Now().AddDate(0, 0, -retentionDays))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Deleted %d published outbox events\n", deleted)
+			return nil
+		},
+	},
+	"retry-dead-outbox": {
+		description: "Requeue outbox events that exceeded outbox.max_attempts",
+		run: func(ctx context.Context, a *app) error {
+			requeued, err := a.outboxRepo.RetryDead(ctx, a.clock.Now())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Requeued %d dead outbox events\n", requeued)
+			return nil
+		},
+	},
+	"prune-stock-changes": {
+		description: "Delete stock change history older than 7 days",
+		run: func(ctx context.Context, a *app) error {
+			deleted, err := a.maintenanceRepo.PruneStockChanges(ctx, a.clock.Now().AddDate(0, 0, -retentionDays))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Deleted %d stock changes\n", deleted)
+			return nil
+		},
+	},
+}
+
+// retentionDays adalah lama riwayat (event outbox yang sudah dipublikasikan, perubahan stok) disimpan,
+// cukup untuk menelusuri masalah pengiriman dan melanjutkan stream SSE yang lama terputus.
+const retentionDays = 7
+
+func (a *app) maintenanceList() error {
+	names := make([]string, 0, len(maintenanceJobs))
+	for name := range maintenanceJobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tDESCRIPTION")
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s\t%s\n", name, maintenanceJobs[name].description)
+	}
+	return tw.Flush()
+}
+
+func (a *app) maintenanceRun(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("maintenance run requires exactly one JOB argument")
+	}
+
+	job, ok := maintenanceJobs[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown maintenance job %q (see: libctl maintenance list)", args[0])
+	}
+
+	return job.run(ctx, a)
+}
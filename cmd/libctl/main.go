@@ -0,0 +1,142 @@
+// Command libctl adalah CLI admin untuk operasi back-office perpustakaan.
+// libctl memakai service layer yang sama dengan API sehingga aturan bisnis (transaksi, locking, validasi)
+// tetap berlaku, dan staf tidak perlu lagi menjalankan SQL mentah ke library_db.
+package main
+
+import (
+	"context"
+	stdErrors "errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/Ar1veeee/library-api/internal/clock"
+	"github.com/Ar1veeee/library-api/internal/config"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/logger"
+	"github.com/Ar1veeee/library-api/internal/repository"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+	"github.com/Ar1veeee/library-api/internal/service"
+)
+
+const usage = `Usage: libctl [config flags] <command> <subcommand> [flags]
+
+Commands:
+  catalog export [-format csv|json] [-o FILE]   Export the book catalog
+  catalog import [-format csv|json] FILE        Create/update books from a file
+  member create -name N -email E [-language L]   Register a new member (L: id|en)
+  loan force-return -id LOAN_ID                 Return a loan on behalf of a member
+  loan overdue [-json]                          List loans past the loan period
+  stock recalculate                             Recompute stock from copies and active loans
+  maintenance list                              List maintenance jobs
+  maintenance run JOB                           Run a maintenance job
+
+Configuration is read the same way as the API: -config FILE (or CONFIG_FILE),
+environment variables (DB_HOST, DB_PASSWORD, ...) and flags such as -database.host.
+`
+
+// app menampung dependency yang dipakai oleh semua command.
+type app struct {
+	bookService     *service.BookService
+	memberService   *service.MemberService
+	loanService     *service.LoanService
+	maintenanceRepo *repository.MaintenanceRepository
+	outboxRepo      *repository.OutboxRepository
+	// clock sama dengan yang dipakai service, sehingga batas retensi maintenance ikut clock.offset.
+	clock clock.Clock
+}
+
+func main() {
+	// Flag konfigurasi (-config, -database.host, ...) ditulis sebelum command, contoh: libctl -config prod.yaml loan overdue
+	cfg, args, err := config.Load(os.Args[0], os.Args[1:])
+	if stdErrors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+
+	if len(args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// Log CLI ditulis ke stderr agar tidak bercampur dengan output command (CSV/JSON) di stdout.
+	slog.SetDefault(logger.New(os.Stderr, cfg.LogLevel, "text"))
+
+	db, err := config.NewDatabase(cfg)
+	if err != nil {
+		fatal(err)
+	}
+	defer db.Close()
+
+	bookRepo := repository.NewBookRepository(db)
+	memberRepo := repository.NewMemberRepository(db)
+	loanRepo := repository.NewLoanRepository(db)
+
+	// Event dari libctl (misalnya force-return) hanya ditulis ke outbox; relay di instance API yang mempublikasikannya.
+	outboxRepo := repository.NewOutboxRepository(db)
+
+	loanPolicy := service.LoanPolicy{
+		MaxActiveLoans: cfg.LoanMaxActive,
+		LoanPeriodDays: cfg.LoanPeriodDays,
+	}
+
+	a := &app{
+		bookService:     service.NewBookService(db, bookRepo),
+		memberService:   service.NewMemberService(memberRepo, loanRepo, cfg.Location()),
+		loanService:     service.NewLoanService(db, bookRepo, memberRepo, loanRepo, repository.NewFineRepository(db), loanPolicy, cfg.Location(), cfg.Clock(), outboxRepo, nil),
+		maintenanceRepo: repository.NewMaintenanceRepository(db),
+		outboxRepo:      outboxRepo,
+		clock:           cfg.Clock(),
+	}
+
+	// Setiap eksekusi libctl mendapat trace ID sendiri, sehingga query DB dari satu command bisa dikorelasikan.
+	ctx := reqctx.WithNewTraceID(context.Background())
+	command, subcommand, args := args[0], args[1], args[2:]
+
+	switch command + " " + subcommand {
+	case "catalog export":
+		err = a.catalogExport(ctx, args)
+	case "catalog import":
+		err = a.catalogImport(ctx, args)
+	case "member create":
+		err = a.memberCreate(ctx, args)
+	case "loan force-return":
+		err = a.loanForceReturn(ctx, args)
+	case "loan overdue":
+		err = a.loanOverdue(ctx, args)
+	case "stock recalculate":
+		err = a.stockRecalculate(ctx)
+	case "maintenance list":
+		err = a.maintenanceList()
+	case "maintenance run":
+		err = a.maintenanceRun(ctx, args)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		// db.Close via defer tidak jalan setelah os.Exit, jadi ditutup manual.
+		db.Close()
+		fatal(err)
+	}
+}
+
+// fatal mencetak error ke stderr, termasuk kode ZYD-ERR jika error berasal dari service layer.
+// Berbeda dengan response API, penyebab internal (Cause) ikut dicetak karena CLI dipakai operator.
+func fatal(err error) {
+	var apiErr errors.APIError
+	if stdErrors.As(err, &apiErr) {
+		fmt.Fprintf(os.Stderr, "error: %s (%s)\n", apiErr.Message, apiErr.ZiyadErrCode)
+		if apiErr.Cause != nil {
+			fmt.Fprintf(os.Stderr, "cause: %v\n", apiErr.Cause)
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,39 @@
+// Package apikey memeriksa API key integrator, dipakai bersama oleh REST (header X-API-Key) dan gRPC
+// (metadata x-api-key) agar aturan perbandingannya tidak bisa berbeda antar transport.
+package apikey
+
+import "crypto/subtle"
+
+// Set adalah daftar API key yang dikenal.
+type Set struct {
+	keys [][]byte
+}
+
+func New(keys []string) *Set {
+	set := &Set{keys: make([][]byte, 0, len(keys))}
+	for _, key := range keys {
+		set.keys = append(set.keys, []byte(key))
+	}
+	return set
+}
+
+// Empty berarti tidak ada key terdaftar, yaitu autentikasi dimatikan (config.Validate hanya mengizinkannya
+// di luar production).
+func (s *Set) Empty() bool {
+	return len(s.keys) == 0
+}
+
+// Valid membandingkan candidate dengan setiap key dalam constant time, dan tetap memeriksa semua key walaupun
+// sudah ada yang cocok, agar key tidak bisa ditebak dari waktu respons.
+func (s *Set) Valid(candidate string) bool {
+	if candidate == "" {
+		return false
+	}
+	valid := false
+	for _, key := range s.keys {
+		if subtle.ConstantTimeCompare(key, []byte(candidate)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
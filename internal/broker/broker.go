@@ -0,0 +1,86 @@
+// Package broker adalah antarmuka minimal ke message broker (NATS, Kafka, dan sejenisnya).
+//
+// MENGAPA interface sekecil ini?
+//   - Subject memetakan ke subject NATS atau topic Kafka; key memetakan ke partition key Kafka,
+//     sehingga pesan dengan key sama tetap berurutan di broker yang mendukungnya
+//   - Adapter broker sungguhan cukup mengimplementasikan Publish tanpa mengubah relay outbox
+//
+// Local adalah pengganti in-process untuk development dan satu instance: pesan diteruskan ke subscriber
+// di proses yang sama, tidak disimpan, dan tidak menyeberang ke instance lain.
+package broker
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Broker mempublikasikan payload ke subject tertentu.
+type Broker interface {
+	Publish(ctx context.Context, subject, key string, payload []byte) error
+}
+
+// Message adalah pesan yang diterima subscriber Local.
+type Message struct {
+	Subject string
+	Key     string
+	Payload []byte
+}
+
+type subscription struct {
+	prefix string
+	ch     chan Message
+}
+
+// Local adalah Broker in-process. Aman dipakai dari banyak goroutine.
+type Local struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   map[int]*subscription
+}
+
+func NewLocal() *Local {
+	return &Local{subs: make(map[int]*subscription)}
+}
+
+// Publish meneruskan pesan ke semua subscriber yang prefix-nya cocok dengan subject.
+// Tidak pernah memblokir: subscriber yang buffer-nya penuh kehilangan pesan tersebut, karena subscriber lambat
+// tidak boleh menahan relay (dan seluruh event setelahnya).
+func (l *Local) Publish(_ context.Context, subject, key string, payload []byte) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	msg := Message{Subject: subject, Key: key, Payload: payload}
+	for _, sub := range l.subs {
+		if !strings.HasPrefix(subject, sub.prefix) {
+			continue
+		}
+		select {
+		case sub.ch <- msg:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe menerima pesan yang subject-nya diawali prefix ("" untuk semua pesan).
+// Fungsi yang dikembalikan menghentikan langganan dan menutup channel; aman dipanggil lebih dari sekali.
+func (l *Local) Subscribe(prefix string, buffer int) (<-chan Message, func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id := l.nextID
+	l.nextID++
+	sub := &subscription{prefix: prefix, ch: make(chan Message, buffer)}
+	l.subs[id] = sub
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			l.mu.Lock()
+			delete(l.subs, id)
+			l.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+}
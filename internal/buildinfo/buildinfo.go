@@ -0,0 +1,53 @@
+// Package buildinfo menyimpan informasi versi binary yang diisi saat build.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Nilai di bawah diisi lewat ldflags, contoh:
+//
+//	go build -ldflags "-X github.com/Ar1veeee/library-api/internal/buildinfo.Version=v1.2.0"
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info adalah ringkasan build yang ditampilkan di endpoint readiness.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get mengembalikan informasi build.
+// Alasan fallback ke debug.ReadBuildInfo: binary yang di-build tanpa ldflags (misalnya go run)
+// tetap menampilkan commit VCS yang dicatat otomatis oleh toolchain Go.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	return info
+}
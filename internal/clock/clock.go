@@ -0,0 +1,67 @@
+// Package clock menyediakan sumber waktu yang bisa diganti, sehingga logika yang bergantung pada waktu
+// (tanggal pinjam, jatuh tempo, overdue) bisa dibuat deterministik dan disimulasikan di staging.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock adalah sumber waktu "sekarang" untuk service.
+// MENGAPA tidak memanggil time.Now() / NOW() langsung?
+//   - Waktu pinjam dulu berasal dari dua sumber (NOW() MySQL dan time.Now() aplikasi) yang bisa berbeda
+//   - Dengan satu Clock yang di-inject, service menentukan waktu sekali dan meneruskannya ke repository,
+//     sehingga skenario "14 hari kemudian" bisa diuji tanpa menunggu atau mengubah jam server
+type Clock interface {
+	Now() time.Time
+}
+
+// System memakai jam sistem.
+type System struct{}
+
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// Offset adalah jam sistem yang digeser sejumlah durasi ("time travel") untuk environment staging,
+// misalnya offset 360h membuat pinjaman hari ini langsung terlihat overdue.
+type Offset struct {
+	offset time.Duration
+}
+
+func NewOffset(offset time.Duration) Offset {
+	return Offset{offset: offset}
+}
+
+func (c Offset) Now() time.Time {
+	return time.Now().Add(c.offset)
+}
+
+// Fixed selalu mengembalikan waktu yang sama sampai diubah dengan Set atau Advance.
+type Fixed struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+func NewFixed(now time.Time) *Fixed {
+	return &Fixed{now: now}
+}
+
+func (c *Fixed) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+func (c *Fixed) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance memajukan waktu, contoh: Advance(15 * 24 * time.Hour) untuk mensimulasikan pinjaman yang terlambat.
+func (c *Fixed) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
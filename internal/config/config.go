@@ -3,50 +3,261 @@ package config
 import (
 	"database/sql"
 	"fmt"
-	"os"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/Ar1veeee/library-api/internal/clock"
+	"github.com/Ar1veeee/library-api/internal/sqlhook"
+	"github.com/go-sql-driver/mysql"
 )
 
+// Environment yang dikenal. Production mengaktifkan pengecekan tambahan (lihat Validate).
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// Config adalah seluruh konfigurasi aplikasi.
+// Setiap field memiliki tag `config` (key di file YAML, juga nama flag) dan `env` (nama environment variable),
+// sehingga satu field cukup dideklarasikan sekali untuk ketiga sumber konfigurasi (lihat Load).
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	ServerPort string
+	Environment string `config:"environment" env:"APP_ENV"`
+
+	DBHost     string `config:"database.host" env:"DB_HOST"`
+	DBPort     string `config:"database.port" env:"DB_PORT"`
+	DBUser     string `config:"database.user" env:"DB_USER"`
+	DBPassword string `config:"database.password" env:"DB_PASSWORD"`
+	DBName     string `config:"database.name" env:"DB_NAME"`
+
+	// Pool koneksi database, lihat NewDatabase.
+	DBMaxOpenConns    int           `config:"database.max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns    int           `config:"database.max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	DBConnMaxLifetime time.Duration `config:"database.conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+
+	ServerPort string `config:"server.port" env:"SERVER_PORT"`
+
+	// Timeout HTTP server. Tanpa timeout, client lambat (atau koneksi yang ditinggal)
+	// bisa menahan goroutine & koneksi DB tanpa batas.
+	ServerReadTimeout       time.Duration `config:"server.read_timeout" env:"SERVER_READ_TIMEOUT"`
+	ServerReadHeaderTimeout time.Duration `config:"server.read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	ServerWriteTimeout      time.Duration `config:"server.write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	ServerIdleTimeout       time.Duration `config:"server.idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+
+	// ShutdownTimeout adalah batas waktu menunggu request yang sedang berjalan selesai saat SIGTERM.
+	ShutdownTimeout time.Duration `config:"server.shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	// ShutdownDrainDelay adalah jeda setelah status berubah "not ready" sebelum listener ditutup,
+	// agar load balancer sempat berhenti mengirim request baru.
+	ShutdownDrainDelay time.Duration `config:"server.shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+
+	// ReadinessTimeout adalah batas waktu pengecekan dependency di /readyz.
+	ReadinessTimeout time.Duration `config:"server.readiness_timeout" env:"READINESS_TIMEOUT"`
+
+	// LoanMaxActive adalah batas buku yang boleh dipinjam bersamaan oleh satu member.
+	LoanMaxActive int `config:"loan.max_active" env:"LOAN_MAX_ACTIVE"`
+	// LoanPeriodDays adalah masa pinjam sebelum pinjaman dianggap terlambat (overdue).
+	LoanPeriodDays int `config:"loan.period_days" env:"LOAN_PERIOD_DAYS"`
+
+	// Timezone adalah nama zona waktu IANA perpustakaan (misalnya Asia/Jakarta).
+	Timezone string `config:"timezone" env:"APP_TIMEZONE"`
+
+	// ClockOffset menggeser jam aplikasi ("time travel") untuk simulasi jatuh tempo di staging.
+	// Ditolak di production oleh Validate.
+	ClockOffset time.Duration `config:"clock.offset" env:"CLOCK_OFFSET"`
+
+	LogLevel  string `config:"log.level" env:"LOG_LEVEL"`   // debug, info, warn, error
+	LogFormat string `config:"log.format" env:"LOG_FORMAT"` // json atau text
+
+	TracingExporter     string  `config:"tracing.exporter" env:"TRACING_EXPORTER"` // none, stdout, atau otlp
+	TracingOTLPEndpoint string  `config:"tracing.otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure bool    `config:"tracing.otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+	TracingSampleRatio  float64 `config:"tracing.sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+
+	// Rate limit token bucket per API key / IP. Write berlaku untuk request selain GET (borrow, return).
+	RateLimitEnabled        bool     `config:"rate_limit.enabled" env:"RATE_LIMIT_ENABLED"`
+	RateLimitPerMinute      int      `config:"rate_limit.per_minute" env:"RATE_LIMIT_PER_MINUTE"`
+	RateLimitBurst          int      `config:"rate_limit.burst" env:"RATE_LIMIT_BURST"`
+	RateLimitWritePerMinute int      `config:"rate_limit.write_per_minute" env:"RATE_LIMIT_WRITE_PER_MINUTE"`
+	RateLimitWriteBurst     int      `config:"rate_limit.write_burst" env:"RATE_LIMIT_WRITE_BURST"`
+	RateLimitAPIKeys        []string `config:"rate_limit.api_keys" env:"RATE_LIMIT_API_KEYS"`
+	RateLimitTrustProxy     bool     `config:"rate_limit.trust_proxy" env:"RATE_LIMIT_TRUST_PROXY"`
+
+	// CORS untuk /api/v1 agar web katalog di origin lain bisa memanggil API dari browser.
+	CORSAllowedOrigins   []string      `config:"cors.allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowedMethods   []string      `config:"cors.allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	CORSAllowedHeaders   []string      `config:"cors.allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	CORSExposedHeaders   []string      `config:"cors.exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+	CORSAllowCredentials bool          `config:"cors.allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	CORSMaxAge           time.Duration `config:"cors.max_age" env:"CORS_MAX_AGE"`
+
+	// SecurityHSTSMaxAge > 0 mengaktifkan header Strict-Transport-Security.
+	SecurityHSTSMaxAge time.Duration `config:"security.hsts_max_age" env:"SECURITY_HSTS_MAX_AGE"`
+
+	// gRPC API untuk layanan internal (kiosk, portal kampus), berjalan di port terpisah dari REST.
+	// GRPCAPIKeys kosong berarti tanpa autentikasi, hanya diizinkan di luar production (lihat Validate).
+	GRPCEnabled bool     `config:"grpc.enabled" env:"GRPC_ENABLED"`
+	GRPCPort    string   `config:"grpc.port" env:"GRPC_PORT"`
+	GRPCAPIKeys []string `config:"grpc.api_keys" env:"GRPC_API_KEYS"`
+
+	// Webhook event peminjaman ke integrator (lihat internal/webhook).
+	// WebhookDispatcherEnabled menjalankan worker pengiriman di instance ini; event tetap diantrekan walaupun false,
+	// sehingga worker bisa dipusatkan di sebagian instance saja.
+	WebhookDispatcherEnabled bool          `config:"webhook.dispatcher_enabled" env:"WEBHOOK_DISPATCHER_ENABLED"`
+	WebhookPollInterval      time.Duration `config:"webhook.poll_interval" env:"WEBHOOK_POLL_INTERVAL"`
+	WebhookTimeout           time.Duration `config:"webhook.timeout" env:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts       int           `config:"webhook.max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookBackoffBase       time.Duration `config:"webhook.backoff_base" env:"WEBHOOK_BACKOFF_BASE"`
+	WebhookBackoffMax        time.Duration `config:"webhook.backoff_max" env:"WEBHOOK_BACKOFF_MAX"`
+	// WebhookOverdueScanInterval adalah jeda pemindaian pinjaman yang baru overdue (event loan.overdue).
+	WebhookOverdueScanInterval time.Duration `config:"webhook.overdue_scan_interval" env:"WEBHOOK_OVERDUE_SCAN_INTERVAL"`
+	// WebhookAPIKeys melindungi endpoint /api/v1/webhooks (header X-API-Key); kosong hanya diizinkan di luar production.
+	WebhookAPIKeys []string `config:"webhook.api_keys" env:"WEBHOOK_API_KEYS"`
+	// WebhookAllowPrivateTargets mengizinkan URL webhook ke alamat loopback/link-local/private, hanya untuk pengujian
+	// lokal (receiver di 127.0.0.1); ditolak di production.
+	WebhookAllowPrivateTargets bool `config:"webhook.allow_private_targets" env:"WEBHOOK_ALLOW_PRIVATE_TARGETS"`
+
+	// Relay transactional outbox (lihat internal/outbox). Hanya satu instance yang aktif sebagai relay pada satu waktu,
+	// sehingga mengaktifkannya di semua instance aman. OutboxSinks berisi tujuan event: webhook, log, broker.
+	// Event yang gagal OutboxMaxAttempts kali menjadi dead dan berhenti menahan aggregate-nya.
+	OutboxRelayEnabled bool          `config:"outbox.relay_enabled" env:"OUTBOX_RELAY_ENABLED"`
+	OutboxPollInterval time.Duration `config:"outbox.poll_interval" env:"OUTBOX_POLL_INTERVAL"`
+	OutboxMaxAttempts  int           `config:"outbox.max_attempts" env:"OUTBOX_MAX_ATTEMPTS"`
+	OutboxSinks        []string      `config:"outbox.sinks" env:"OUTBOX_SINKS"`
+
+	// Stream SSE ketersediaan buku (lihat internal/stockstream). StockStreamPollInterval adalah jeda membaca
+	// perubahan stok dari database (perubahan dari instance lain); perubahan di instance ini dikirim tanpa menunggu.
+	StockStreamPollInterval      time.Duration `config:"stock_stream.poll_interval" env:"STOCK_STREAM_POLL_INTERVAL"`
+	StockStreamHeartbeatInterval time.Duration `config:"stock_stream.heartbeat_interval" env:"STOCK_STREAM_HEARTBEAT_INTERVAL"`
 }
 
-func Load() *Config {
+// Default mengembalikan konfigurasi bawaan, cocok untuk development lokal dengan docker-compose.
+// Beberapa nilai (misalnya password "secret") sengaja tidak aman dan ditolak oleh Validate di production.
+func Default() *Config {
 	return &Config{
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "3306"),
-		DBUser:     getEnv("DB_USER", "root"),
-		DBPassword: getEnv("DB_PASSWORD", "secret"),
-		DBName:     getEnv("DB_NAME", "library_db"),
-		ServerPort: getEnv("SERVER_PORT", "8080"),
+		Environment: EnvDevelopment,
+
+		DBHost:     "localhost",
+		DBPort:     "3306",
+		DBUser:     "root",
+		DBPassword: "secret",
+		DBName:     "library_db",
+
+		DBMaxOpenConns:    25,
+		DBMaxIdleConns:    5,
+		DBConnMaxLifetime: 5 * time.Minute,
+
+		ServerPort: "8080",
+
+		ServerReadTimeout:       10 * time.Second,
+		ServerReadHeaderTimeout: 5 * time.Second,
+		ServerWriteTimeout:      15 * time.Second,
+		ServerIdleTimeout:       60 * time.Second,
+
+		ShutdownTimeout:    20 * time.Second,
+		ShutdownDrainDelay: 5 * time.Second,
+
+		ReadinessTimeout: 2 * time.Second,
+
+		LoanMaxActive:  3,
+		LoanPeriodDays: 14,
+
+		Timezone: "Asia/Jakarta",
+
+		LogLevel:  "info",
+		LogFormat: "json",
+
+		TracingExporter:     "none",
+		TracingOTLPInsecure: true,
+		TracingSampleRatio:  1.0,
+
+		RateLimitEnabled:        true,
+		RateLimitPerMinute:      300,
+		RateLimitBurst:          50,
+		RateLimitWritePerMinute: 30,
+		RateLimitWriteBurst:     5,
+
+		CORSAllowedMethods:   []string{"GET", "POST", "DELETE", "OPTIONS"},
+		CORSAllowedHeaders:   []string{"Content-Type", "Accept", "Accept-Language", "X-Request-ID", "X-API-Key"},
+		CORSExposedHeaders:   []string{"X-Request-ID", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
+		CORSAllowCredentials: false,
+		CORSMaxAge:           10 * time.Minute,
+
+		GRPCEnabled: false,
+		GRPCPort:    "9090",
+
+		WebhookDispatcherEnabled:   true,
+		WebhookPollInterval:        5 * time.Second,
+		WebhookTimeout:             10 * time.Second,
+		WebhookMaxAttempts:         8,
+		WebhookBackoffBase:         30 * time.Second,
+		WebhookBackoffMax:          time.Hour,
+		WebhookOverdueScanInterval: 15 * time.Minute,
+
+		OutboxRelayEnabled: true,
+		OutboxPollInterval: time.Second,
+		OutboxMaxAttempts:  20,
+		OutboxSinks:        []string{"webhook"},
+
+		StockStreamPollInterval:      time.Second,
+		StockStreamHeartbeatInterval: 15 * time.Second,
 	}
 }
 
+// Location mengembalikan zona waktu perpustakaan untuk menampilkan timestamp di response.
+// Timezone sudah divalidasi oleh Validate, sehingga fallback UTC hanya untuk Config yang dibuat manual.
+func (c *Config) Location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Clock mengembalikan sumber waktu aplikasi: jam sistem, atau jam yang digeser ClockOffset.
+func (c *Config) Clock() clock.Clock {
+	if c.ClockOffset == 0 {
+		return clock.System{}
+	}
+	return clock.NewOffset(c.ClockOffset)
+}
+
 func NewDatabase(cfg *Config) (*sql.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
 		cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName,
 	)
 
-	db, err := sql.Open("mysql", dsn)
+	mysqlCfg, err := mysql.ParseDSN(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// MENGAPA session MySQL dipaksa UTC?
+	// - Kolom TIMESTAMP dikembalikan dalam time_zone session (default SYSTEM, tergantung server DB),
+	//   sedangkan driver membacanya sebagai Loc (UTC); jika keduanya berbeda, waktu bergeser beberapa jam
+	// - Dengan session dan Loc sama-sama UTC, time.Time dari DB selalu instant yang benar;
+	//   konversi ke zona waktu perpustakaan dilakukan hanya saat membentuk response
+	mysqlCfg.Loc = time.UTC
+	if mysqlCfg.Params == nil {
+		mysqlCfg.Params = make(map[string]string)
+	}
+	mysqlCfg.Params["time_zone"] = "'+00:00'"
+
+	connector, err := mysql.NewConnector(mysqlCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	// Connector dibungkus sqlhook agar setiap query tercatat dengan trace_id request (LOG_LEVEL=debug)
+	// dan menjadi span OpenTelemetry di bawah span service/repository yang memanggilnya.
+	db := sql.OpenDB(sqlhook.Wrap(connector, sqlhook.TraceHook{}, sqlhook.LogHook{}))
+
 	// MENGAPA mengatur pengaturan pool koneksi?
 	// - Untuk mengatur concurrent requests (API diakses banyak user sekaligus) dengan baik
 	// - MaxOpenConns: Maksimal jumlah koneksi yang boleh dibuka (hindari overload DB)
 	// - MaxIdleConns: Maksimal koneksi yang tidak langsung ditutup (hindari overhead create/destroy dan reuseable)
 	// - ConnMaxLifetime: Batas maksimal umur 1 koneksi yang wajib ditutup dan diganti baru (hindari stale connections)
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
 
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
@@ -54,11 +265,3 @@ func NewDatabase(cfg *Config) (*sql.DB, error) {
 
 	return db, nil
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-
-	return defaultValue
-}
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv adalah environment variable alternatif untuk flag -config.
+const ConfigFileEnv = "CONFIG_FILE"
+
+// setting menghubungkan satu field Config dengan key file/flag dan nama env-nya.
+type setting struct {
+	key   string
+	env   string
+	value reflect.Value
+}
+
+// Load membangun Config berlapis, dari prioritas terendah ke tertinggi:
+//  1. Default()
+//  2. File YAML dari flag -config atau env CONFIG_FILE (opsional)
+//  3. Environment variable (DB_HOST, SERVER_PORT, ...)
+//  4. Flag command line (-server.port 9090, -log.level debug, ...)
+//
+// Setelah itu Validate dijalankan, sehingga konfigurasi yang salah menghentikan proses saat startup,
+// bukan saat request pertama. args adalah argumen setelah nama program; argumen sisa setelah flag
+// (misalnya subcommand "migrate up") dikembalikan sebagai rest.
+//
+// MENGAPA nilai yang tidak valid sekarang menjadi error (sebelumnya diam-diam memakai default)?
+// - SHUTDOWN_TIMEOUT=30 (tanpa satuan) dulu diabaikan tanpa pesan, dan operator mengira timeout sudah 30 detik
+func Load(program string, args []string) (cfg *Config, rest []string, err error) {
+	cfg = Default()
+	settings := settingsOf(cfg)
+
+	flags := flag.NewFlagSet(program, flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv(ConfigFileEnv), "path to YAML config file (env "+ConfigFileEnv+")")
+
+	// Flag dikumpulkan dulu dan baru diterapkan setelah file & env, agar flag selalu menang.
+	flagValues := make(map[string]string)
+	for _, s := range settings {
+		s := s
+		collect := func(raw string) error {
+			flagValues[s.key] = raw
+			return nil
+		}
+		// Flag boolean boleh ditulis tanpa nilai (-rate-limit.trust-proxy), seperti flag.Bool.
+		if s.value.Kind() == reflect.Bool {
+			flags.BoolFunc(flagName(s.key), "overrides env "+s.env, collect)
+		} else {
+			flags.Func(flagName(s.key), "overrides env "+s.env, collect)
+		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *configFile != "" {
+		if err := applyFile(settings, *configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if raw, ok := os.LookupEnv(s.env); ok && raw != "" {
+			if err := setValue(s.value, raw); err != nil {
+				return nil, nil, fmt.Errorf("env %s: %w", s.env, err)
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if raw, ok := flagValues[s.key]; ok {
+			if err := setValue(s.value, raw); err != nil {
+				return nil, nil, fmt.Errorf("flag -%s: %w", flagName(s.key), err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, flags.Args(), nil
+}
+
+// settingsOf membaca tag `config` dan `env` dari setiap field Config.
+func settingsOf(cfg *Config) []setting {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+
+	settings := make([]setting, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("config")
+		if key == "" {
+			continue
+		}
+		settings = append(settings, setting{key: key, env: field.Tag.Get("env"), value: v.Field(i)})
+	}
+
+	return settings
+}
+
+// flagName mengubah key file menjadi nama flag, contoh: database.max_open_conns → database.max-open-conns.
+func flagName(key string) string {
+	return strings.ReplaceAll(key, "_", "-")
+}
+
+// applyFile membaca file YAML bertingkat (server:, database:, ...) dan menerapkan setiap key-nya.
+// Key yang tidak dikenal ditolak agar salah ketik (misalnya "max_open_con") tidak diam-diam diabaikan.
+func applyFile(settings []setting, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+
+	var document map[string]any
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten("", document, values)
+
+	byKey := make(map[string]setting, len(settings))
+	for _, s := range settings {
+		byKey[s.key] = s
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		s, ok := byKey[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key", key))
+			continue
+		}
+		if err := setValue(s.value, values[key]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("config file %s: %w", path, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// flatten mengubah map bertingkat menjadi key bertitik dengan nilai string,
+// sehingga file, env, dan flag memakai parser yang sama (setValue).
+func flatten(prefix string, node map[string]any, out map[string]string) {
+	for name, value := range node {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			flatten(key, value, out)
+		case []any:
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = fmt.Sprint(item)
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+// setValue mengisi field dari string sesuai tipenya.
+// Daftar ([]string) ditulis dipisah koma, durasi memakai format time.ParseDuration ("15s", "5m").
+func setValue(v reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	switch v.Interface().(type) {
+	case string:
+		v.SetString(raw)
+	case int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(int64(n))
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(b)
+	case float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use a unit, e.g. 15s or 5m)", raw)
+		}
+		v.SetInt(int64(d))
+	case []string:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+
+	return nil
+}
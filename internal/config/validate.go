@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	// Database zona waktu IANA ikut di-embed, karena image alpine tidak selalu memiliki /usr/share/zoneinfo.
+	_ "time/tzdata"
+)
+
+// insecurePasswords adalah password bawaan/contoh yang tidak boleh dipakai di production.
+var insecurePasswords = map[string]struct{}{
+	"":         {},
+	"secret":   {},
+	"password": {},
+	"root":     {},
+}
+
+// Validate memeriksa seluruh konfigurasi dan mengembalikan semua masalah sekaligus,
+// sehingga operator tidak perlu memperbaiki satu per satu sambil me-restart aplikasi.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(oneOf(c.Environment, EnvDevelopment, EnvStaging, EnvProduction),
+		"environment: must be one of development, staging, production (got %q)", c.Environment)
+
+	check(c.DBHost != "", "database.host: must not be empty")
+	check(validPort(c.DBPort), "database.port: invalid port %q", c.DBPort)
+	check(c.DBUser != "", "database.user: must not be empty")
+	check(c.DBName != "", "database.name: must not be empty")
+	check(c.DBMaxOpenConns > 0, "database.max_open_conns: must be greater than 0")
+	check(c.DBMaxIdleConns >= 0 && c.DBMaxIdleConns <= c.DBMaxOpenConns,
+		"database.max_idle_conns: must be between 0 and max_open_conns (%d)", c.DBMaxOpenConns)
+	check(c.DBConnMaxLifetime > 0, "database.conn_max_lifetime: must be greater than 0")
+
+	check(validPort(c.ServerPort), "server.port: invalid port %q", c.ServerPort)
+	for _, timeout := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"server.read_timeout", c.ServerReadTimeout},
+		{"server.read_header_timeout", c.ServerReadHeaderTimeout},
+		{"server.write_timeout", c.ServerWriteTimeout},
+		{"server.idle_timeout", c.ServerIdleTimeout},
+		{"server.shutdown_timeout", c.ShutdownTimeout},
+		{"server.readiness_timeout", c.ReadinessTimeout},
+	} {
+		check(timeout.value > 0, "%s: must be greater than 0", timeout.key)
+	}
+	check(c.ShutdownDrainDelay >= 0, "server.shutdown_drain_delay: must not be negative")
+
+	check(c.LoanMaxActive > 0, "loan.max_active: must be greater than 0")
+	check(c.LoanPeriodDays > 0, "loan.period_days: must be greater than 0")
+
+	if _, err := time.LoadLocation(c.Timezone); err != nil || c.Timezone == "" {
+		errs = append(errs, fmt.Errorf("timezone: unknown IANA timezone %q", c.Timezone))
+	}
+
+	check(oneOf(c.LogLevel, "debug", "info", "warn", "error"),
+		"log.level: must be one of debug, info, warn, error (got %q)", c.LogLevel)
+	check(oneOf(c.LogFormat, "json", "text"), "log.format: must be json or text (got %q)", c.LogFormat)
+
+	check(oneOf(c.TracingExporter, "none", "stdout", "otlp"),
+		"tracing.exporter: must be one of none, stdout, otlp (got %q)", c.TracingExporter)
+	check(c.TracingSampleRatio >= 0 && c.TracingSampleRatio <= 1, "tracing.sample_ratio: must be between 0 and 1")
+
+	if c.RateLimitEnabled {
+		check(c.RateLimitPerMinute > 0 && c.RateLimitBurst > 0, "rate_limit: per_minute and burst must be greater than 0")
+		check(c.RateLimitWritePerMinute > 0 && c.RateLimitWriteBurst > 0,
+			"rate_limit: write_per_minute and write_burst must be greater than 0")
+	}
+
+	allowAllOrigins := contains(c.CORSAllowedOrigins, "*")
+	check(!(allowAllOrigins && c.CORSAllowCredentials),
+		"cors.allow_credentials: cannot be combined with allowed_origins \"*\"")
+	check(c.CORSMaxAge >= 0, "cors.max_age: must not be negative")
+	check(c.SecurityHSTSMaxAge >= 0, "security.hsts_max_age: must not be negative")
+
+	if c.GRPCEnabled {
+		check(validPort(c.GRPCPort), "grpc.port: invalid port %q", c.GRPCPort)
+		check(c.GRPCPort != c.ServerPort, "grpc.port: must differ from server.port (%s)", c.ServerPort)
+	}
+
+	for _, interval := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"webhook.poll_interval", c.WebhookPollInterval},
+		{"webhook.timeout", c.WebhookTimeout},
+		{"webhook.backoff_base", c.WebhookBackoffBase},
+		{"webhook.overdue_scan_interval", c.WebhookOverdueScanInterval},
+	} {
+		check(interval.value > 0, "%s: must be greater than 0", interval.key)
+	}
+	check(c.WebhookBackoffMax >= c.WebhookBackoffBase, "webhook.backoff_max: must be at least backoff_base (%s)", c.WebhookBackoffBase)
+	check(c.WebhookMaxAttempts > 0, "webhook.max_attempts: must be greater than 0")
+
+	check(c.OutboxPollInterval > 0, "outbox.poll_interval: must be greater than 0")
+	check(c.OutboxMaxAttempts > 0, "outbox.max_attempts: must be greater than 0")
+	check(len(c.OutboxSinks) > 0, "outbox.sinks: must not be empty")
+	for _, sink := range c.OutboxSinks {
+		check(oneOf(sink, "webhook", "log", "broker"), "outbox.sinks: must be webhook, log or broker (got %q)", sink)
+	}
+
+	check(c.StockStreamPollInterval > 0, "stock_stream.poll_interval: must be greater than 0")
+	check(c.StockStreamHeartbeatInterval > 0, "stock_stream.heartbeat_interval: must be greater than 0")
+
+	// MENGAPA production menolak nilai bawaan?
+	// - Default dibuat agar `docker compose up` langsung jalan, sehingga sengaja tidak aman
+	// - Lupa meng-set DB_PASSWORD di production harus gagal saat deploy, bukan diam-diam memakai "secret"
+	if c.Environment == EnvProduction {
+		_, insecure := insecurePasswords[c.DBPassword]
+		check(!insecure, "database.password: default or empty password is not allowed in production")
+		check(!allowAllOrigins, "cors.allowed_origins: \"*\" is not allowed in production")
+		check(c.ClockOffset == 0, "clock.offset: time travel is not allowed in production")
+		check(!c.GRPCEnabled || len(c.GRPCAPIKeys) > 0, "grpc.api_keys: must not be empty in production")
+		check(len(c.WebhookAPIKeys) > 0, "webhook.api_keys: must not be empty in production")
+		check(!c.WebhookAllowPrivateTargets, "webhook.allow_private_targets: not allowed in production")
+	}
+
+	return errors.Join(errs...)
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+func oneOf(value string, allowed ...string) bool {
+	return contains(allowed, value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -13,3 +13,36 @@ type BooksListResponse struct {
 	Total int            `json:"total"`
 	Books []BookResponse `json:"books"`
 }
+
+// StockChangeEvent represents isi "data" event SSE stok pada /books/stream dan /books/{id}/stream.
+// Stock adalah stok setelah perubahan, Delta selisihnya (-1 pinjam, +1 kembali, lainnya koreksi stok).
+type StockChangeEvent struct {
+	BookID int `json:"book_id"`
+	Stock  int `json:"stock"`
+	Delta  int `json:"delta"`
+}
+
+// CatalogEntry represents satu baris katalog untuk import/export
+type CatalogEntry struct {
+	ID          int    `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Author      string `json:"author"`
+	TotalCopies int    `json:"total_copies"`
+	Stock       int    `json:"stock"`
+}
+
+// ImportCatalogResult represents ringkasan hasil import katalog
+type ImportCatalogResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+// StockCorrection represents koreksi stok hasil perhitungan ulang
+type StockCorrection struct {
+	BookID      int    `json:"book_id"`
+	Title       string `json:"title"`
+	TotalCopies int    `json:"total_copies"`
+	ActiveLoans int    `json:"active_loans"`
+	OldStock    int    `json:"old_stock"`
+	NewStock    int    `json:"new_stock"`
+}
@@ -5,14 +5,53 @@ package dto
 // - Konsistensi format error di seluruh API
 // - Memudahkan client untuk parsing error
 // - TraceID untuk debugging dan correlation logs
+//
+// Violations & Metadata bersifat opsional (omitempty), sehingga client lama yang hanya membaca
+// message dan ziyad_error_code tetap berjalan tanpa perubahan.
 type ErrorResponse struct {
-	Message      string `json:"message"`
-	ZiyadErrCode string `json:"ziyad_error_code"`
-	TraceID      string `json:"trace_id"`
+	Message      string           `json:"message"`
+	ZiyadErrCode string           `json:"ziyad_error_code"`
+	TraceID      string           `json:"trace_id"`
+	Violations   []FieldViolation `json:"violations,omitempty"`
+	Metadata     map[string]any   `json:"metadata,omitempty"`
+}
+
+// FieldViolation represents satu field request yang tidak valid
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
 
 // SuccessResponse represents generic success response
 type SuccessResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
+
+	// MessageID adalah pesan di catalog i18n; mapper.RespondSuccess mengisi Message sesuai bahasa request.
+	MessageID string `json:"-"`
+}
+
+// ProblemDetails adalah format error RFC 7807 (application/problem+json), dikirim jika client memintanya lewat header Accept.
+// Kode ZYD-ERR, trace ID, violations & metadata ikut sebagai extension member dengan nama yang sama
+// seperti ErrorResponse, sehingga client bisa berpindah format tanpa kehilangan informasi.
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance,omitempty"`
+
+	ZiyadErrCode string           `json:"ziyad_error_code"`
+	TraceID      string           `json:"trace_id"`
+	Violations   []FieldViolation `json:"violations,omitempty"`
+	Metadata     map[string]any   `json:"metadata,omitempty"`
+}
+
+// GraphQLRequest adalah body POST /graphql (atau query string GET) sesuai spesifikasi GraphQL over HTTP.
+type GraphQLRequest struct {
+	Query         string         `json:"query" validate:"required"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	Extensions    map[string]any `json:"extensions,omitempty"`
 }
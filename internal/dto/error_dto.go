@@ -0,0 +1,11 @@
+package dto
+
+// ErrorCodeResponse represents satu entry katalog kode error (GET /api/v1/errors)
+type ErrorCodeResponse struct {
+	Code        string `json:"code"`
+	HTTPStatus  int    `json:"http_status"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	// Messages berisi pesan umum kode ini per bahasa, misalnya {"id": "Stok buku habis", "en": "Book is out of stock"}.
+	Messages map[string]string `json:"messages"`
+}
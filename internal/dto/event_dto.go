@@ -0,0 +1,17 @@
+package dto
+
+// Payload "data" untuk event webhook. loan.created memakai LoanDetail dan loan.overdue memakai OverdueLoan,
+// sama dengan response REST-nya.
+
+type LoanReturnedEvent struct {
+	LoanID     int    `json:"loan_id"`
+	MemberID   int    `json:"member_id"`
+	BookID     int    `json:"book_id"`
+	ReturnedAt string `json:"returned_at"`
+}
+
+type BookOutOfStockEvent struct {
+	BookID int    `json:"book_id"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+}
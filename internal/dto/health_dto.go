@@ -0,0 +1,45 @@
+package dto
+
+import "github.com/Ar1veeee/library-api/internal/buildinfo"
+
+// LivenessResponse represents response GET /livez
+type LivenessResponse struct {
+	Status  string `json:"status"`
+	Service string `json:"service"`
+}
+
+// ReadinessResponse represents response GET /readyz
+type ReadinessResponse struct {
+	Status    string                 `json:"status"`
+	Service   string                 `json:"service"`
+	Checks    map[string]HealthCheck `json:"checks"`
+	Database  DatabasePoolStats      `json:"database"`
+	Migration MigrationVersion       `json:"migration"`
+	Build     buildinfo.Info         `json:"build"`
+}
+
+// HealthCheck represents hasil satu pemeriksaan dependency
+type HealthCheck struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DatabasePoolStats represents ringkasan sql.DBStats
+type DatabasePoolStats struct {
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDuration       string `json:"wait_duration"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
+
+// MigrationVersion represents versi schema database dibanding versi yang dikenal binary
+type MigrationVersion struct {
+	Current int64 `json:"current"`
+	Latest  int64 `json:"latest"`
+	Pending int   `json:"pending"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// HoldDetail represents satu reservasi beserta posisinya di antrean buku
+type HoldDetail struct {
+	HoldID    int     `json:"hold_id"`
+	MemberID  int     `json:"member_id"`
+	BookID    int     `json:"book_id"`
+	Status    string  `json:"status"`
+	CreatedAt string  `json:"created_at"`
+	ClosedAt  *string `json:"closed_at,omitempty"`
+	// Position adalah posisi di antrean (1 = terdepan), hanya untuk reservasi waiting.
+	Position *int `json:"position,omitempty"`
+}
@@ -20,3 +20,40 @@ type LoanDetail struct {
 	BookAuthor string `json:"book_author"`
 	BorrowedAt string `json:"borrowed_at"`
 }
+
+// OverdueLoan represents pinjaman aktif yang melewati masa pinjam
+type OverdueLoan struct {
+	LoanID      int    `json:"loan_id"`
+	MemberID    int    `json:"member_id"`
+	MemberName  string `json:"member_name"`
+	MemberEmail string `json:"member_email"`
+	BookID      int    `json:"book_id"`
+	BookTitle   string `json:"book_title"`
+	BorrowedAt  string `json:"borrowed_at"`
+	DueAt       string `json:"due_at"`
+	DaysOverdue int    `json:"days_overdue"`
+}
+
+// LoanRecord adalah satu pinjaman tanpa detail buku/member, dipakai GraphQL yang mengambil relasi lewat data loader.
+type LoanRecord struct {
+	LoanID     int     `json:"loan_id"`
+	MemberID   int     `json:"member_id"`
+	BookID     int     `json:"book_id"`
+	BorrowedAt string  `json:"borrowed_at"`
+	ReturnedAt *string `json:"returned_at,omitempty"`
+	DueAt      string  `json:"due_at"`
+	Status     string  `json:"status"`
+	Overdue    bool    `json:"overdue"`
+}
+
+// FineRecord adalah denda keterlambatan satu pinjaman. Amount dalam satuan mata uang terkecil (rupiah).
+type FineRecord struct {
+	FineID      int     `json:"fine_id"`
+	LoanID      int     `json:"loan_id"`
+	MemberID    int     `json:"member_id"`
+	BookID      int     `json:"book_id"`
+	DaysOverdue int     `json:"days_overdue"`
+	Amount      int     `json:"amount"`
+	CreatedAt   string  `json:"created_at"`
+	PaidAt      *string `json:"paid_at,omitempty"`
+}
@@ -18,3 +18,19 @@ type LoanHistoryItem struct {
 	ReturnedAt *string `json:"returned_at,omitempty"`
 	Status     string  `json:"status"`
 }
+
+// CreateMemberRequest represents data untuk mendaftarkan member baru
+type CreateMemberRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+	// Language opsional; dipakai sebagai bahasa pesan API jika client tidak mengirim Accept-Language.
+	Language string `json:"language,omitempty" validate:"omitempty,oneof=id en"`
+}
+
+// MemberResponse represents single member response
+type MemberResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Language string `json:"language,omitempty"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+// CreateWebhookRequest represents request body untuk POST /api/v1/webhooks
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,http_url,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=loan.created loan.returned loan.overdue book.out_of_stock"`
+}
+
+// WebhookResponse represents satu subscription webhook
+type WebhookResponse struct {
+	ID        int      `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedAt string   `json:"created_at"`
+	// Secret untuk memverifikasi header X-Library-Signature; hanya dikirim sekali saat subscription dibuat.
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookDeliveryResponse represents satu entry delivery log / dead-letter
+type WebhookDeliveryResponse struct {
+	ID             int64   `json:"id"`
+	SubscriptionID int     `json:"subscription_id"`
+	EventID        string  `json:"event_id"`
+	EventType      string  `json:"event_type"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int    `json:"last_status_code,omitempty"`
+	LastError      *string `json:"last_error,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+}
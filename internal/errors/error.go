@@ -1,9 +1,10 @@
 package errors
 
 import (
-	"crypto/rand"
-	"encoding/hex"
-	"time"
+	"fmt"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/i18n"
 )
 
 // APIError represents custom error response format
@@ -11,32 +12,146 @@ type APIError struct {
 	Message      string `json:"message"`
 	ZiyadErrCode string `json:"ziyad_err_code"`
 	TraceID      string `json:"trace_id"`
+
+	// Violations berisi pelanggaran per field untuk ErrCodeInvalidInput, agar client bisa menandai input yang salah.
+	Violations []Violation `json:"violations,omitempty"`
+	// Metadata adalah konteks tambahan yang bisa dibaca mesin, misalnya batas kuota atau waktu tunggu rate limit.
+	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// MessageID & Args menyimpan pesan dalam bentuk yang bisa diterjemahkan (lihat Localized).
+	// Message tetap diisi versi bahasa default agar log & pemanggil non-HTTP (libctl) membaca teks yang sama.
+	MessageID string `json:"-"`
+	Args      []any  `json:"-"`
+
+	// Cause adalah error internal penyebabnya (misalnya error driver MySQL). Tidak pernah dikirim ke client:
+	// hanya dicatat di log bersama trace ID oleh Resolve.
+	Cause error `json:"-"`
+}
+
+// Violation adalah satu input yang tidak valid.
+type Violation struct {
+	// Field memakai nama di request (member_id, atau nama path parameter seperti id).
+	Field string `json:"field"`
+	// Rule adalah aturan yang dilanggar, misalnya required, gt, email, type, unknown_field.
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+
+	MessageID string `json:"-"`
+	Args      []any  `json:"-"`
+}
+
+// NewViolation membuat pelanggaran field dengan pesan dari catalog i18n.
+func NewViolation(field, rule, messageID string, args ...any) Violation {
+	return Violation{
+		Field:     field,
+		Rule:      rule,
+		Message:   i18n.T(i18n.Default, messageID, args...),
+		MessageID: messageID,
+		Args:      args,
+	}
 }
 
 func (e APIError) Error() string {
 	return e.Message
 }
 
+// Unwrap membuat errors.Is/As bisa memeriksa penyebab aslinya, misalnya errors.Is(err, sql.ErrConnDone).
+func (e APIError) Unwrap() error {
+	return e.Cause
+}
+
+// NewAPIError membuat error bisnis dengan kode ZYD-ERR.
+// TraceID sengaja dibiarkan kosong: ID diisi oleh Resolve dari request ID di context,
+// sehingga nilainya sama dengan X-Request-ID dan trace_id di log (bukan ID acak baru per error).
 func NewAPIError(message, code string) APIError {
 	return APIError{
 		Message:      message,
 		ZiyadErrCode: code,
-		TraceID:      GenerateTraceID(),
 	}
 }
 
-// GenerateTraceID generates random string untuk tracking request
-// MENGAPA menggunakan crypto/rand?
-// - Lebih secure dan random dibanding math/rand
-// - Menghindari collision antar trace_id
-func GenerateTraceID() string {
-	b := make([]byte, 16)
-	if _, err := rand.Read(b); err != nil {
-		return time.Now().Format("20060102150405") + "-fallback"
+// NewLocalizedError membuat error bisnis dengan pesan dari catalog i18n, sehingga bisa diterjemahkan
+// sesuai bahasa client saat response ditulis.
+func NewLocalizedError(code, messageID string, args ...any) APIError {
+	return APIError{
+		Message:      i18n.T(i18n.Default, messageID, args...),
+		ZiyadErrCode: code,
+		MessageID:    messageID,
+		Args:         args,
+	}
+}
+
+// Localized mengembalikan salinan error dengan message & violations dalam bahasa lang.
+// Error tanpa message ID (misalnya pesan kegagalan internal yang berisi detail error) tetap memakai Message apa adanya
+// untuk bahasa default, dan pesan umum kode error-nya untuk bahasa lain.
+func (e APIError) Localized(lang i18n.Lang) APIError {
+	if len(e.Violations) > 0 {
+		violations := make([]Violation, len(e.Violations))
+		messages := make([]string, len(e.Violations))
+		for i, v := range e.Violations {
+			if v.MessageID != "" {
+				v.Message = i18n.T(lang, v.MessageID, v.Args...)
+			}
+			violations[i] = v
+			messages[i] = v.Message
+		}
+		e.Violations = violations
+
+		// Ringkasan validasi disusun dari pesan violation, jadi harus dibangun ulang dari versi terjemahannya.
+		if e.MessageID == i18n.MsgValidationFailed {
+			e.Args = []any{strings.Join(messages, "; ")}
+		}
+	}
+
+	switch {
+	case e.MessageID != "":
+		e.Message = i18n.T(lang, e.MessageID, e.Args...)
+	case lang != i18n.Default:
+		if definition, ok := Lookup(e.ZiyadErrCode); ok {
+			e.Message = i18n.T(lang, definition.MessageID)
+		}
+	}
+
+	return e
+}
+
+// Internal membuat error ErrCodeTxFailed untuk kegagalan infrastruktur (query, commit, dll).
+// MENGAPA tidak memasukkan err ke Message seperti sebelumnya ("Gagal memeriksa member: Error 1054 ...")?
+//   - Pesan driver membocorkan nama tabel/kolom, host database, dan detail lain yang tidak relevan bagi client
+//   - Client cukup tahu operasinya gagal + trace_id; detail lengkap ada di log dengan trace ID yang sama
+//
+// operation (misalnya "memeriksa member") ikut dibungkus ke Cause agar log tetap menunjukkan langkah yang gagal.
+func Internal(operation string, cause error) APIError {
+	return NewLocalizedError(ErrCodeTxFailed, i18n.MsgErrTxFailed).
+		WithCause(fmt.Errorf("%s: %w", operation, cause))
+}
+
+// WithCause mengembalikan salinan error dengan penyebab internal untuk logging.
+func (e APIError) WithCause(cause error) APIError {
+	e.Cause = cause
+	return e
+}
+
+// WithViolations mengembalikan salinan error dengan daftar pelanggaran field.
+// Receiver berupa nilai (bukan pointer) agar bisa dirangkai langsung: NewAPIError(...).WithViolations(...).
+func (e APIError) WithViolations(violations ...Violation) APIError {
+	e.Violations = append(append([]Violation(nil), e.Violations...), violations...)
+	return e
+}
+
+// WithMetadata mengembalikan salinan error dengan satu entry metadata tambahan.
+func (e APIError) WithMetadata(key string, value any) APIError {
+	metadata := make(map[string]any, len(e.Metadata)+1)
+	for k, v := range e.Metadata {
+		metadata[k] = v
 	}
-	return hex.EncodeToString(b)
+	metadata[key] = value
+	e.Metadata = metadata
+	return e
 }
 
+// Setiap kode baru wajib didaftarkan juga di registry (registry.go) beserta HTTP status-nya;
+// TestEveryCodeIsRegistered gagal jika terlewat.
 const (
 	ErrCodeStockEmpty      = "ZYD-ERR-001" // Stok buku habis
 	ErrCodeQuotaExceeded   = "ZYD-ERR-002" // Kuota member habis
@@ -45,4 +160,6 @@ const (
 	ErrCodeNotFound        = "ZYD-ERR-005" // Resource not found
 	ErrCodeInvalidInput    = "ZYD-ERR-006" // Invalid input data
 	ErrCodeAlreadyReturned = "ZYD-ERR-007" // Buku sudah dikembalikan
+	ErrCodeRateLimited     = "ZYD-ERR-008" // Terlalu banyak request (rate limit)
+	ErrCodeUnauthenticated = "ZYD-ERR-009" // API key tidak ada atau tidak dikenal (gRPC)
 )
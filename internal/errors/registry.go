@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/i18n"
+)
+
+// Definition mendeskripsikan satu kode ZYD-ERR: HTTP status, penjelasan untuk developer client,
+// dan pesan umum di catalog i18n.
+type Definition struct {
+	Code        string
+	HTTPStatus  int
+	Description string
+	// MessageID adalah pesan umum kode ini (dipakai sebagai "title" problem+json dan fallback terjemahan).
+	MessageID string
+}
+
+// registry adalah satu-satunya sumber pemetaan kode → HTTP status.
+// MENGAPA satu registry, bukan switch di mapper?
+//   - Dulu kode baru yang lupa ditambahkan ke switch diam-diam menjadi 400; sekarang kode yang tidak terdaftar
+//     terlihat jelas (500 + log) dan katalog GET /api/v1/errors otomatis ikut lengkap
+//   - Urutan slice menentukan urutan di katalog, sehingga output stabil
+var registry = []Definition{
+	{
+		Code:        ErrCodeStockEmpty,
+		HTTPStatus:  http.StatusConflict,
+		Description: "Stok buku yang diminta sudah habis; coba lagi setelah ada pengembalian.",
+		MessageID:   i18n.MsgErrStockEmpty,
+	},
+	{
+		Code:        ErrCodeQuotaExceeded,
+		HTTPStatus:  http.StatusConflict,
+		Description: "Member sudah mencapai batas pinjaman aktif; metadata.max_active_loans berisi batasnya.",
+		MessageID:   i18n.MsgErrQuotaExceeded,
+	},
+	{
+		Code:        ErrCodeAlreadyBorrowed,
+		HTTPStatus:  http.StatusConflict,
+		Description: "Member sedang meminjam buku yang sama dan belum mengembalikannya.",
+		MessageID:   i18n.MsgErrAlreadyBorrowed,
+	},
+	{
+		Code:        ErrCodeTxFailed,
+		HTTPStatus:  http.StatusInternalServerError,
+		Description: "Kegagalan internal (database/transaksi). Laporkan trace_id; detail hanya tersedia di log server.",
+		MessageID:   i18n.MsgErrTxFailed,
+	},
+	{
+		Code:        ErrCodeNotFound,
+		HTTPStatus:  http.StatusNotFound,
+		Description: "Buku, member, atau peminjaman yang dirujuk tidak ditemukan.",
+		MessageID:   i18n.MsgErrNotFound,
+	},
+	{
+		Code:        ErrCodeInvalidInput,
+		HTTPStatus:  http.StatusBadRequest,
+		Description: "Request tidak valid (JSON rusak, field tidak dikenal, atau aturan validasi); lihat violations.",
+		MessageID:   i18n.MsgErrInvalidInput,
+	},
+	{
+		Code:        ErrCodeAlreadyReturned,
+		HTTPStatus:  http.StatusConflict,
+		Description: "Peminjaman sudah dikembalikan sebelumnya.",
+		MessageID:   i18n.MsgErrAlreadyReturned,
+	},
+	{
+		Code:        ErrCodeRateLimited,
+		HTTPStatus:  http.StatusTooManyRequests,
+		Description: "Batas request terlampaui; tunggu sesuai header Retry-After atau metadata.retry_after_seconds.",
+		MessageID:   i18n.MsgErrRateLimited,
+	},
+	{
+		Code:        ErrCodeUnauthenticated,
+		HTTPStatus:  http.StatusUnauthorized,
+		Description: "API key tidak dikirim atau tidak dikenal; kirim key yang terdaftar di header X-API-Key (REST) atau metadata x-api-key (gRPC).",
+		MessageID:   i18n.MsgErrUnauthenticated,
+	},
+}
+
+// Definitions mengembalikan salinan semua kode yang terdaftar, sesuai urutan registry.
+func Definitions() []Definition {
+	return append([]Definition(nil), registry...)
+}
+
+// Lookup mencari definisi kode error; ok=false jika kode tidak terdaftar.
+func Lookup(code string) (Definition, bool) {
+	for _, definition := range registry {
+		if definition.Code == code {
+			return definition, true
+		}
+	}
+	return Definition{}, false
+}
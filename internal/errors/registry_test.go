@@ -0,0 +1,71 @@
+package errors_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Ar1veeee/library-api/internal/errors"
+)
+
+// TestEveryCodeIsRegistered gagal jika ada konstanta ErrCode* di error.go yang tidak punya entry di registry.
+// Konstanta dibaca dari source (bukan daftar manual) sehingga kode baru otomatis ikut diperiksa.
+func TestEveryCodeIsRegistered(t *testing.T) {
+	codes := errCodeConstants(t)
+	if len(codes) == 0 {
+		t.Fatal("no ErrCode* constants found in error.go")
+	}
+
+	for name, code := range codes {
+		if _, ok := errors.Lookup(code); !ok {
+			t.Errorf("%s (%s) is not registered in registry.go", name, code)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, definition := range errors.Definitions() {
+		if seen[definition.Code] {
+			t.Errorf("%s is registered more than once", definition.Code)
+		}
+		seen[definition.Code] = true
+	}
+}
+
+// errCodeConstants mengembalikan nama → nilai semua konstanta string ErrCode* di error.go.
+func errCodeConstants(t *testing.T) map[string]string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "error.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse error.go: %v", err)
+	}
+
+	codes := make(map[string]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if !strings.HasPrefix(name.Name, "ErrCode") || i >= len(valueSpec.Values) {
+					continue
+				}
+				literal, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || literal.Kind != token.STRING {
+					t.Fatalf("%s must be a string literal", name.Name)
+				}
+				code, err := strconv.Unquote(literal.Value)
+				if err != nil {
+					t.Fatalf("unquote %s: %v", name.Name, err)
+				}
+				codes[name.Name] = code
+			}
+		}
+	}
+	return codes
+}
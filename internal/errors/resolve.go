@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/logger"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+)
+
+// Resolve menyiapkan error yang akan dikirim ke client oleh transport mana pun (REST, gRPC, GraphQL):
+//   - error yang bukan APIError menjadi pesan internal generik (detailnya hanya di log)
+//   - TraceID diisi dari context request, sama dengan trace_id di log
+//   - HTTP status dibaca dari registry; kode yang tidak terdaftar adalah bug, sehingga dijawab 500 dan dicatat
+//
+// Resolve juga mencatat error ke log (lihat logResolved), sehingga transport cukup mengubah hasilnya ke format
+// wire masing-masing (JSON/problem+json, status gRPC, extensions GraphQL).
+//
+// MENGAPA di package errors, bukan di tiap transport?
+//   - Sebelumnya blok yang sama disalin di mapper, grpcapi, dan gqlapi; perubahan aturan log/status di satu
+//     transport mudah terlewat di transport lain
+func Resolve(ctx context.Context, err error) (APIError, int) {
+	log := logger.FromContext(ctx)
+
+	var apiErr APIError
+	if !stdErrors.As(err, &apiErr) {
+		log.Error("unhandled error", "error", err)
+		apiErr = NewLocalizedError(ErrCodeTxFailed, i18n.MsgErrInternal)
+	}
+
+	if traceID := reqctx.TraceID(ctx); traceID != "" {
+		apiErr.TraceID = traceID
+	}
+
+	httpStatus := http.StatusInternalServerError
+	if definition, ok := Lookup(apiErr.ZiyadErrCode); ok {
+		httpStatus = definition.HTTPStatus
+	} else {
+		log.Error("unregistered error code", "error_code", apiErr.ZiyadErrCode)
+	}
+
+	logResolved(ctx, apiErr, httpStatus)
+	return apiErr, httpStatus
+}
+
+// logResolved mencatat kegagalan server (5xx) sebagai error, dan penolakan yang membawa Cause sebagai warning.
+// Cause hanya pernah muncul di log (dengan trace_id dari logger.FromContext), tidak pernah di response.
+func logResolved(ctx context.Context, apiErr APIError, httpStatus int) {
+	log := logger.FromContext(ctx)
+
+	switch {
+	case httpStatus >= http.StatusInternalServerError:
+		log.Error("request failed", "error_code", apiErr.ZiyadErrCode, "error", apiErr.Message, "cause", causeString(apiErr))
+	case apiErr.Cause != nil:
+		log.Warn("request rejected", "error_code", apiErr.ZiyadErrCode, "error", apiErr.Message, "cause", causeString(apiErr))
+	}
+}
+
+func causeString(err APIError) string {
+	if err.Cause == nil {
+		return ""
+	}
+	return err.Cause.Error()
+}
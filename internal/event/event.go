@@ -0,0 +1,78 @@
+// Package event mendefinisikan domain event peminjaman yang dikirim ke pihak luar (webhook).
+//
+// MENGAPA service hanya bergantung pada interface Publisher?
+//   - LoanService tidak perlu tahu event dikirim lewat webhook, log, atau sistem lain
+//   - Relay outbox (internal/outbox) meneruskan event yang sama ke beberapa Publisher sekaligus
+package event
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Type adalah nama event, juga dipakai integrator saat mendaftarkan webhook.
+type Type string
+
+const (
+	LoanCreated    Type = "loan.created"
+	LoanReturned   Type = "loan.returned"
+	LoanOverdue    Type = "loan.overdue"
+	BookOutOfStock Type = "book.out_of_stock"
+)
+
+// Types berisi semua event yang bisa dilanggan, sesuai urutan di dokumentasi.
+var Types = []Type{LoanCreated, LoanReturned, LoanOverdue, BookOutOfStock}
+
+// Event adalah payload yang dikirim ke integrator.
+// ID unik per event sehingga penerima bisa mengabaikan duplikat (pengiriman bersifat at-least-once).
+type Event struct {
+	ID   string `json:"id"`
+	Type Type   `json:"type"`
+	// Aggregate adalah entitas sumber event (lihat LoanAggregate/BookAggregate). Event dengan Aggregate sama
+	// dipublikasikan sesuai urutan terjadinya. Tidak ikut di payload: kontrak webhook tidak berubah.
+	Aggregate  string    `json:"-"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// New membuat event dengan ID acak. occurredAt sebaiknya sudah dalam zona waktu perpustakaan dan dibulatkan
+// ke detik, agar formatnya sama dengan timestamp di response API.
+func New(eventType Type, aggregate string, occurredAt time.Time, data any) Event {
+	return Event{ID: newID(), Type: eventType, Aggregate: aggregate, OccurredAt: occurredAt.Truncate(time.Second), Data: data}
+}
+
+// LoanAggregate dan BookAggregate membentuk nilai Event.Aggregate, misalnya "loan:12".
+func LoanAggregate(loanID int) string { return "loan:" + strconv.Itoa(loanID) }
+func BookAggregate(bookID int) string { return "book:" + strconv.Itoa(bookID) }
+
+// Decode membaca kembali event yang disimpan sebagai JSON (misalnya di outbox). Data dibiarkan sebagai
+// json.RawMessage sehingga di-encode ulang persis sama; Aggregate diisi pemanggil karena tidak ada di payload.
+func Decode(payload []byte, aggregate string) (Event, error) {
+	var e struct {
+		ID         string          `json:"id"`
+		Type       Type            `json:"type"`
+		OccurredAt time.Time       `json:"occurred_at"`
+		Data       json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return Event{}, err
+	}
+	return Event{ID: e.ID, Type: e.Type, Aggregate: aggregate, OccurredAt: e.OccurredAt, Data: e.Data}, nil
+}
+
+// Publisher meneruskan event ke tujuan (misalnya antrean pengiriman webhook, log, atau broker).
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return "evt_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,98 @@
+package gqlapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Batas batch loader. wait cukup singkat agar tidak terasa di latency, tetapi cukup lama untuk
+// mengumpulkan key dari resolver yang dijalankan paralel oleh graphql-go untuk item list yang sama.
+const (
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 100
+)
+
+// loader adalah data loader sederhana: Load dari banyak resolver dikumpulkan selama loaderWait,
+// lalu diambil dengan satu panggilan fetch. Hasil di-cache selama umur loader (satu request),
+// sehingga key yang sama tidak pernah diambil dua kali.
+//
+// MENGAPA menulis loader sendiri?
+//   - Kebutuhannya kecil (key int, beberapa jenis data per member/buku) dan tidak perlu dependency tambahan
+//   - fetch memanggil service batch (GetBooksByIDs, ...) yang mengembalikan map, sehingga key yang tidak
+//     ditemukan cukup ditandai found=false, tanpa konvensi urutan hasil seperti library dataloader
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	cache   map[K]*loaderResult[V]
+	pending *loaderBatch[K, V]
+}
+
+type loaderResult[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+type loaderBatch[K comparable, V any] struct {
+	keys    []K
+	results []*loaderResult[V]
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, cache: make(map[K]*loaderResult[V])}
+}
+
+// Load mengembalikan nilai untuk key; found=false jika fetch tidak mengembalikan key tersebut.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	l.mu.Lock()
+	result, ok := l.cache[key]
+	if !ok {
+		result = &loaderResult[V]{done: make(chan struct{})}
+		l.cache[key] = result
+
+		if l.pending == nil {
+			batch := &loaderBatch[K, V]{}
+			l.pending = batch
+			time.AfterFunc(loaderWait, func() { l.dispatch(ctx, batch) })
+		}
+		batch := l.pending
+		batch.keys = append(batch.keys, key)
+		batch.results = append(batch.results, result)
+
+		if len(batch.keys) >= loaderMaxBatch {
+			go l.dispatch(ctx, batch)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-result.done:
+		return result.value, result.found, result.err
+	case <-ctx.Done():
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
+
+// dispatch menjalankan fetch untuk satu batch; aman dipanggil dua kali (timer dan batas ukuran batch).
+func (l *loader[K, V]) dispatch(ctx context.Context, batch *loaderBatch[K, V]) {
+	l.mu.Lock()
+	if l.pending != batch {
+		// Batch sudah di-dispatch oleh pemanggil lain.
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, batch.keys)
+	for i, key := range batch.keys {
+		result := batch.results[i]
+		result.value, result.found = values[key]
+		result.err = err
+		close(result.done)
+	}
+}
@@ -0,0 +1,41 @@
+package gqlapi
+
+import (
+	"context"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/service"
+)
+
+type loadersKey struct{}
+
+// loaders adalah data loader milik satu request GraphQL.
+// MENGAPA per request, bukan global?
+//   - Cache loader adalah snapshot untuk satu response; dibagi antar request berarti stok buku basi
+//   - Batch hanya mengumpulkan key dari resolver request yang sama
+type loaders struct {
+	books       *loader[int, dto.BookResponse]
+	members     *loader[int, dto.MemberResponse]
+	memberLoans *loader[int, []dto.LoanRecord]
+	memberHolds *loader[int, []dto.HoldDetail]
+	memberFines *loader[int, []dto.FineRecord]
+}
+
+func newLoaders(bookService *service.BookService, memberService *service.MemberService, loanService *service.LoanService, holdService *service.HoldService) *loaders {
+	return &loaders{
+		books:       newLoader(bookService.GetBooksByIDs),
+		members:     newLoader(memberService.GetMembersByIDs),
+		memberLoans: newLoader(loanService.GetLoansByMemberIDs),
+		memberHolds: newLoader(holdService.GetHoldsByMemberIDs),
+		memberFines: newLoader(loanService.GetFinesByMemberIDs),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFrom selalu berhasil untuk context dari Server.Execute.
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
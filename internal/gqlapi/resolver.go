@@ -0,0 +1,263 @@
+package gqlapi
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/service"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// rootResolver menjawab field Query. Resolver tipe (book, member, loan, hold, fine) membungkus DTO service,
+// sehingga format data (timestamp, status) sama dengan REST.
+type rootResolver struct {
+	bookService *service.BookService
+}
+
+func (r *rootResolver) Books(ctx context.Context) ([]*bookResolver, error) {
+	books, err := r.bookService.GetAllBooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*bookResolver, len(books.Books))
+	for i, book := range books.Books {
+		resolvers[i] = &bookResolver{book: book}
+	}
+	return resolvers, nil
+}
+
+func (r *rootResolver) Book(ctx context.Context, args struct{ ID graphql.ID }) (*bookResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return loadBook(ctx, id)
+}
+
+func (r *rootResolver) Member(ctx context.Context, args struct{ ID graphql.ID }) (*memberResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return loadMember(ctx, id)
+}
+
+type bookResolver struct {
+	book dto.BookResponse
+}
+
+func (r *bookResolver) ID() graphql.ID { return toID(r.book.ID) }
+func (r *bookResolver) Title() string  { return r.book.Title }
+func (r *bookResolver) Author() string { return r.book.Author }
+func (r *bookResolver) Stock() int32   { return int32(r.book.Stock) }
+
+type memberResolver struct {
+	member dto.MemberResponse
+}
+
+func (r *memberResolver) ID() graphql.ID { return toID(r.member.ID) }
+func (r *memberResolver) Name() string   { return r.member.Name }
+
+func (r *memberResolver) Language() *string {
+	if r.member.Language == "" {
+		return nil
+	}
+	return &r.member.Language
+}
+
+func (r *memberResolver) Loans(ctx context.Context, args struct{ Status *string }) ([]*loanResolver, error) {
+	loans, _, err := loadersFrom(ctx).memberLoans.Load(ctx, r.member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*loanResolver, 0, len(loans))
+	for _, loan := range loans {
+		if args.Status != nil && loanStatus(loan) != *args.Status {
+			continue
+		}
+		resolvers = append(resolvers, &loanResolver{loan: loan})
+	}
+	return resolvers, nil
+}
+
+func (r *memberResolver) Holds(ctx context.Context, args struct{ Status *string }) ([]*holdResolver, error) {
+	holds, _, err := loadersFrom(ctx).memberHolds.Load(ctx, r.member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*holdResolver, 0, len(holds))
+	for _, hold := range holds {
+		if args.Status != nil && strings.ToUpper(hold.Status) != *args.Status {
+			continue
+		}
+		resolvers = append(resolvers, &holdResolver{hold: hold})
+	}
+	return resolvers, nil
+}
+
+func (r *memberResolver) Fines(ctx context.Context, args struct{ Unpaid *bool }) ([]*fineResolver, error) {
+	fines, _, err := loadersFrom(ctx).memberFines.Load(ctx, r.member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*fineResolver, 0, len(fines))
+	for _, fine := range fines {
+		if args.Unpaid != nil && *args.Unpaid != (fine.PaidAt == nil) {
+			continue
+		}
+		resolvers = append(resolvers, &fineResolver{fine: fine})
+	}
+	return resolvers, nil
+}
+
+type loanResolver struct {
+	loan dto.LoanRecord
+}
+
+func (r *loanResolver) ID() graphql.ID      { return toID(r.loan.LoanID) }
+func (r *loanResolver) BorrowedAt() string  { return r.loan.BorrowedAt }
+func (r *loanResolver) ReturnedAt() *string { return r.loan.ReturnedAt }
+func (r *loanResolver) DueAt() string       { return r.loan.DueAt }
+func (r *loanResolver) Status() string      { return loanStatus(r.loan) }
+func (r *loanResolver) Overdue() bool       { return r.loan.Overdue }
+
+func (r *loanResolver) Book(ctx context.Context) (*bookResolver, error) {
+	return requireBook(ctx, r.loan.BookID)
+}
+
+func (r *loanResolver) Member(ctx context.Context) (*memberResolver, error) {
+	return requireMember(ctx, r.loan.MemberID)
+}
+
+// Fine memakai loader denda milik member peminjam, sehingga daftar pinjaman dengan field fine tetap satu query.
+func (r *loanResolver) Fine(ctx context.Context) (*fineResolver, error) {
+	fines, _, err := loadersFrom(ctx).memberFines.Load(ctx, r.loan.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	for _, fine := range fines {
+		if fine.LoanID == r.loan.LoanID {
+			return &fineResolver{fine: fine}, nil
+		}
+	}
+	return nil, nil
+}
+
+type holdResolver struct {
+	hold dto.HoldDetail
+}
+
+func (r *holdResolver) ID() graphql.ID    { return toID(r.hold.HoldID) }
+func (r *holdResolver) Status() string    { return strings.ToUpper(r.hold.Status) }
+func (r *holdResolver) CreatedAt() string { return r.hold.CreatedAt }
+func (r *holdResolver) ClosedAt() *string { return r.hold.ClosedAt }
+
+func (r *holdResolver) Position() *int32 {
+	if r.hold.Position == nil {
+		return nil
+	}
+	position := int32(*r.hold.Position)
+	return &position
+}
+
+func (r *holdResolver) Book(ctx context.Context) (*bookResolver, error) {
+	return requireBook(ctx, r.hold.BookID)
+}
+
+func (r *holdResolver) Member(ctx context.Context) (*memberResolver, error) {
+	return requireMember(ctx, r.hold.MemberID)
+}
+
+type fineResolver struct {
+	fine dto.FineRecord
+}
+
+func (r *fineResolver) ID() graphql.ID     { return toID(r.fine.FineID) }
+func (r *fineResolver) DaysOverdue() int32 { return int32(r.fine.DaysOverdue) }
+func (r *fineResolver) Amount() int32      { return int32(r.fine.Amount) }
+func (r *fineResolver) CreatedAt() string  { return r.fine.CreatedAt }
+func (r *fineResolver) PaidAt() *string    { return r.fine.PaidAt }
+
+// Loan diambil dari riwayat pinjaman member (loader memberLoans), bukan query per loan ID.
+func (r *fineResolver) Loan(ctx context.Context) (*loanResolver, error) {
+	loans, _, err := loadersFrom(ctx).memberLoans.Load(ctx, r.fine.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	for _, loan := range loans {
+		if loan.LoanID == r.fine.LoanID {
+			return &loanResolver{loan: loan}, nil
+		}
+	}
+	return nil, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgLoanNotFound)
+}
+
+func (r *fineResolver) Book(ctx context.Context) (*bookResolver, error) {
+	return requireBook(ctx, r.fine.BookID)
+}
+
+func (r *fineResolver) Member(ctx context.Context) (*memberResolver, error) {
+	return requireMember(ctx, r.fine.MemberID)
+}
+
+// requireBook dan requireMember dipakai relasi non-null di schema: baris loans/holds/fines selalu punya
+// book & member (foreign key), sehingga data yang hilang berarti inkonsistensi dan dilaporkan sebagai error.
+func requireBook(ctx context.Context, id int) (*bookResolver, error) {
+	book, err := loadBook(ctx, id)
+	if err == nil && book == nil {
+		return nil, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgBookNotFound)
+	}
+	return book, err
+}
+
+func requireMember(ctx context.Context, id int) (*memberResolver, error) {
+	member, err := loadMember(ctx, id)
+	if err == nil && member == nil {
+		return nil, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgMemberNotFound)
+	}
+	return member, err
+}
+
+// loadBook mengembalikan (nil, nil) jika buku tidak ada, sama dengan konvensi repository.
+func loadBook(ctx context.Context, id int) (*bookResolver, error) {
+	book, found, err := loadersFrom(ctx).books.Load(ctx, id)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &bookResolver{book: book}, nil
+}
+
+func loadMember(ctx context.Context, id int) (*memberResolver, error) {
+	member, found, err := loadersFrom(ctx).members.Load(ctx, id)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &memberResolver{member: member}, nil
+}
+
+// loanStatus mengubah status REST ("loan"/"returned") menjadi nilai enum LoanStatus.
+func loanStatus(loan dto.LoanRecord) string {
+	return strings.ToUpper(loan.Status)
+}
+
+func toID(id int) graphql.ID {
+	return graphql.ID(strconv.Itoa(id))
+}
+
+// parseID memvalidasi argumen id dengan aturan yang sama dengan path parameter REST (bilangan positif).
+func parseID(id graphql.ID) (int, error) {
+	value, err := strconv.Atoi(string(id))
+	if err != nil || value <= 0 {
+		return 0, errors.NewLocalizedError(errors.ErrCodeInvalidInput, i18n.MsgRequestPathInt, "id").
+			WithViolations(errors.NewViolation("id", "gt", i18n.MsgRequestPathInt, "id"))
+	}
+	return value, nil
+}
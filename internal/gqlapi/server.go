@@ -0,0 +1,90 @@
+// Package gqlapi menyediakan endpoint GraphQL read-only untuk dashboard member portal.
+//
+// MENGAPA GraphQL di samping REST?
+//   - Dashboard butuh member, pinjaman, dan detail buku sekaligus; lewat REST itu beberapa request berurutan
+//   - Resolver memanggil service yang sama dengan REST, dan relasi diambil dengan data loader (lihat loader.go)
+//     agar jumlah query SQL tidak tumbuh mengikuti jumlah baris (N+1)
+package gqlapi
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/metrics"
+	"github.com/Ar1veeee/library-api/internal/service"
+	"github.com/graph-gophers/graphql-go"
+	gqlErrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth membatasi kedalaman query (misalnya member → loans → member → loans ...)
+// agar satu request tidak bisa memicu fetch berantai tanpa batas.
+const maxDepth = 6
+
+type Server struct {
+	schema        *graphql.Schema
+	bookService   *service.BookService
+	memberService *service.MemberService
+	loanService   *service.LoanService
+	holdService   *service.HoldService
+}
+
+// NewServer mem-parse schema yang di-embed. Schema yang tidak cocok dengan resolver adalah bug
+// yang harus menggagalkan startup, sehingga memakai MustParseSchema.
+func NewServer(bookService *service.BookService, memberService *service.MemberService, loanService *service.LoanService, holdService *service.HoldService) *Server {
+	return &Server{
+		schema: graphql.MustParseSchema(schemaSDL, &rootResolver{bookService: bookService},
+			graphql.MaxDepth(maxDepth),
+		),
+		bookService:   bookService,
+		memberService: memberService,
+		loanService:   loanService,
+		holdService:   holdService,
+	}
+}
+
+// Execute menjalankan satu operasi GraphQL dengan data loader baru, lalu mengubah error resolver
+// menjadi error GraphQL berisi extensions kode ZYD-ERR (lihat toGraphQLError).
+func (s *Server) Execute(ctx context.Context, lang i18n.Lang, req dto.GraphQLRequest) *graphql.Response {
+	ctx = withLoaders(ctx, newLoaders(s.bookService, s.memberService, s.loanService, s.holdService))
+
+	response := s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	for _, queryErr := range response.Errors {
+		if queryErr.ResolverError != nil {
+			toGraphQLError(ctx, lang, queryErr)
+		}
+	}
+	return response
+}
+
+// toGraphQLError mengisi message & extensions dari APIError, mengikuti aturan yang sama dengan REST:
+// status, log, dan fallback pesan generik dari errors.Resolve, lalu pesan diterjemahkan sesuai bahasa client.
+//
+// Extensions: code (ZYD-ERR), trace_id, serta violations/metadata jika ada.
+func toGraphQLError(ctx context.Context, lang i18n.Lang, queryErr *gqlErrors.QueryError) {
+	apiErr, httpStatus := errors.Resolve(ctx, queryErr.ResolverError)
+	metrics.ObserveAPIError(apiErr.ZiyadErrCode, httpStatus)
+
+	apiErr = apiErr.Localized(lang)
+	queryErr.Message = apiErr.Message
+	queryErr.Extensions = map[string]interface{}{
+		"code":     apiErr.ZiyadErrCode,
+		"trace_id": apiErr.TraceID,
+	}
+
+	if len(apiErr.Violations) > 0 {
+		violations := make([]dto.FieldViolation, len(apiErr.Violations))
+		for i, v := range apiErr.Violations {
+			violations[i] = dto.FieldViolation{Field: v.Field, Rule: v.Rule, Message: v.Message}
+		}
+		queryErr.Extensions["violations"] = violations
+	}
+	if len(apiErr.Metadata) > 0 {
+		queryErr.Extensions["metadata"] = apiErr.Metadata
+	}
+}
@@ -0,0 +1,62 @@
+package grpcapi
+
+import (
+	"context"
+
+	libraryv1 "github.com/Ar1veeee/library-api/gen/library/v1"
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/service"
+)
+
+type catalogServer struct {
+	libraryv1.UnimplementedCatalogServiceServer
+	bookService *service.BookService
+}
+
+func newCatalogServer(bookService *service.BookService) *catalogServer {
+	return &catalogServer{bookService: bookService}
+}
+
+func (s *catalogServer) ListBooks(ctx context.Context, _ *libraryv1.ListBooksRequest) (*libraryv1.ListBooksResponse, error) {
+	ctx, span := tracer.Start(ctx, "CatalogServer.ListBooks")
+	defer span.End()
+
+	books, err := s.bookService.GetAllBooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &libraryv1.ListBooksResponse{Total: int64(books.Total)}
+	for _, book := range books.Books {
+		response.Books = append(response.Books, toBook(book))
+	}
+	return response, nil
+}
+
+func (s *catalogServer) GetBook(ctx context.Context, req *libraryv1.GetBookRequest) (*libraryv1.Book, error) {
+	ctx, span := tracer.Start(ctx, "CatalogServer.GetBook")
+	defer span.End()
+
+	// Setara dengan mapper.PathInt di REST: ID harus bilangan positif.
+	if req.GetId() <= 0 {
+		return nil, errors.NewLocalizedError(errors.ErrCodeInvalidInput, i18n.MsgRequestPathInt, "id").
+			WithViolations(errors.NewViolation("id", "gt", i18n.MsgRequestPathInt, "id"))
+	}
+
+	book, err := s.bookService.GetBookByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toBook(*book), nil
+}
+
+func toBook(book dto.BookResponse) *libraryv1.Book {
+	return &libraryv1.Book{
+		Id:     int64(book.ID),
+		Title:  book.Title,
+		Author: book.Author,
+		Stock:  int64(book.Stock),
+	}
+}
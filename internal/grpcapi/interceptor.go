@@ -0,0 +1,165 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/apikey"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+	"github.com/Ar1veeee/library-api/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata yang dibaca server, setara dengan header REST (X-Request-ID, X-API-Key, Accept-Language).
+// Key metadata gRPC selalu huruf kecil.
+const (
+	metadataRequestID      = "x-request-id"
+	metadataAPIKey         = "x-api-key"
+	metadataAcceptLanguage = "accept-language"
+)
+
+// maxRequestIDLength sama dengan batas middleware RequestID, agar ID dari client tidak membengkakkan log.
+const maxRequestIDLength = 128
+
+var tracer = tracing.Tracer("github.com/Ar1veeee/library-api/internal/grpcapi")
+
+// requestContext menyiapkan reqctx.Info dan server span untuk setiap RPC, seperti middleware RequestID + Tracing di REST.
+// Trace ID dikembalikan ke client lewat header x-request-id.
+func requestContext(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	traceID := firstValue(md, metadataRequestID)
+	if traceID == "" || len(traceID) > maxRequestIDLength {
+		traceID = reqctx.GenerateTraceID()
+	}
+	ctx = reqctx.With(ctx, &reqctx.Info{TraceID: traceID, Route: info.FullMethod})
+	_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, traceID))
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	service, method := splitMethod(info.FullMethod)
+	ctx, span := tracer.Start(ctx, info.FullMethod,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+			attribute.String("rpc.request_id", traceID),
+		),
+	)
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if code == codes.Internal || code == codes.Unknown {
+		span.SetStatus(otelcodes.Error, code.String())
+	}
+	return resp, err
+}
+
+// accessLog menulis satu baris log per RPC dengan field yang sama dengan access log REST.
+func accessLog(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		attrs := []slog.Attr{
+			slog.String("trace_id", reqctx.TraceID(ctx)),
+			slog.String("method", info.FullMethod),
+			slog.String("grpc_code", code.String()),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		}
+		if reqInfo := reqctx.From(ctx); reqInfo != nil && reqInfo.MemberID > 0 {
+			attrs = append(attrs, slog.Int("member_id", reqInfo.MemberID))
+		}
+
+		level := slog.LevelInfo
+		if code == codes.Internal || code == codes.Unknown {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "grpc request", attrs...)
+
+		return resp, err
+	}
+}
+
+// errorStatus mengubah error dari handler/service menjadi status gRPC (lihat toStatus).
+// Bahasa pesan ditentukan setelah handler selesai, karena preferensi bahasa member baru diketahui dari service.
+func errorStatus(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	return nil, toStatus(ctx, language(ctx), err).Err()
+}
+
+// auth memeriksa metadata x-api-key untuk semua RPC library.v1, satu interceptor untuk ketiga service.
+// MENGAPA health check dikecualikan?
+//   - Probe orchestrator (grpc_health_probe) tidak membawa API key, sama seperti /livez dan /readyz di REST
+func auth(apiKeys []string) grpc.UnaryServerInterceptor {
+	keys := apikey.New(apiKeys)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if keys.Empty() || strings.HasPrefix(info.FullMethod, "/grpc.health.v1.Health/") {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		if !keys.Valid(firstValue(md, metadataAPIKey)) {
+			return nil, errors.NewLocalizedError(errors.ErrCodeUnauthenticated, i18n.MsgErrUnauthenticated)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// language menentukan bahasa pesan dengan prioritas yang sama dengan REST (i18n.Resolve).
+func language(ctx context.Context) i18n.Lang {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return i18n.Resolve(ctx, strings.Join(md.Get(metadataAcceptLanguage), ","))
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// splitMethod memecah "/library.v1.LoanService/BorrowBook" menjadi service dan method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service, method
+}
+
+// metadataCarrier membuat metadata gRPC bisa dibaca propagator OpenTelemetry (traceparent/tracestate).
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	return firstValue(metadata.MD(c), key)
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
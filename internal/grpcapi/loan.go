@@ -0,0 +1,71 @@
+package grpcapi
+
+import (
+	"context"
+
+	libraryv1 "github.com/Ar1veeee/library-api/gen/library/v1"
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+	"github.com/Ar1veeee/library-api/internal/service"
+	"github.com/Ar1veeee/library-api/internal/validation"
+)
+
+type loanServer struct {
+	libraryv1.UnimplementedLoanServiceServer
+	loanService *service.LoanService
+}
+
+func newLoanServer(loanService *service.LoanService) *loanServer {
+	return &loanServer{loanService: loanService}
+}
+
+func (s *loanServer) BorrowBook(ctx context.Context, req *libraryv1.BorrowBookRequest) (*libraryv1.Loan, error) {
+	ctx, span := tracer.Start(ctx, "LoanServer.BorrowBook")
+	defer span.End()
+
+	request := dto.BorrowBookRequest{MemberID: int(req.GetMemberId()), BookID: int(req.GetBookId())}
+	if err := validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	reqctx.SetMemberID(ctx, request.MemberID)
+
+	loan, err := s.loanService.BorrowBook(ctx, request.MemberID, request.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &libraryv1.Loan{
+		LoanId:     int64(loan.LoanID),
+		MemberId:   int64(loan.MemberID),
+		BookId:     int64(loan.BookID),
+		BookTitle:  loan.BookTitle,
+		BookAuthor: loan.BookAuthor,
+		BorrowedAt: loan.BorrowedAt,
+	}, nil
+}
+
+func (s *loanServer) ReturnBook(ctx context.Context, req *libraryv1.ReturnBookRequest) (*libraryv1.ReturnBookResponse, error) {
+	ctx, span := tracer.Start(ctx, "LoanServer.ReturnBook")
+	defer span.End()
+
+	request := dto.ReturnBookRequest{MemberID: int(req.GetMemberId()), BookID: int(req.GetBookId())}
+	if err := validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	reqctx.SetMemberID(ctx, request.MemberID)
+
+	if err := s.loanService.ReturnBook(ctx, request.MemberID, request.BookID); err != nil {
+		return nil, err
+	}
+
+	return &libraryv1.ReturnBookResponse{Message: i18n.T(language(ctx), i18n.MsgLoanReturned)}, nil
+}
+
+// validateRequest memakai tag validate DTO REST, sehingga aturan dan pesan violation sama di kedua API.
+// Nama field violation mengikuti tag json, yang sama dengan nama field di proto (member_id, book_id).
+func validateRequest(request any) error {
+	return validation.Validate(request)
+}
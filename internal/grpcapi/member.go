@@ -0,0 +1,81 @@
+package grpcapi
+
+import (
+	"context"
+
+	libraryv1 "github.com/Ar1veeee/library-api/gen/library/v1"
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+	"github.com/Ar1veeee/library-api/internal/service"
+)
+
+type memberServer struct {
+	libraryv1.UnimplementedMemberServiceServer
+	memberService *service.MemberService
+}
+
+func newMemberServer(memberService *service.MemberService) *memberServer {
+	return &memberServer{memberService: memberService}
+}
+
+// CreateMember tidak memvalidasi request sendiri: MemberService.CreateMember sudah memanggil validation.Validate.
+func (s *memberServer) CreateMember(ctx context.Context, req *libraryv1.CreateMemberRequest) (*libraryv1.Member, error) {
+	ctx, span := tracer.Start(ctx, "MemberServer.CreateMember")
+	defer span.End()
+
+	member, err := s.memberService.CreateMember(ctx, dto.CreateMemberRequest{
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+		Language: req.GetLanguage(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &libraryv1.Member{
+		Id:       int64(member.ID),
+		Name:     member.Name,
+		Email:    member.Email,
+		Language: member.Language,
+	}, nil
+}
+
+func (s *memberServer) ListMemberLoans(ctx context.Context, req *libraryv1.ListMemberLoansRequest) (*libraryv1.ListMemberLoansResponse, error) {
+	ctx, span := tracer.Start(ctx, "MemberServer.ListMemberLoans")
+	defer span.End()
+
+	request := struct {
+		MemberID int `json:"member_id" validate:"required,gt=0"`
+	}{MemberID: int(req.GetMemberId())}
+	if err := validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	reqctx.SetMemberID(ctx, request.MemberID)
+
+	loans, err := s.memberService.GetMemberLoans(ctx, request.MemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &libraryv1.ListMemberLoansResponse{
+		MemberId:   int64(loans.MemberID),
+		MemberName: loans.MemberName,
+		TotalLoans: int64(loans.TotalLoans),
+	}
+	for _, loan := range loans.Loans {
+		item := &libraryv1.LoanHistoryItem{
+			LoanId:     int64(loan.LoanID),
+			BookId:     int64(loan.BookID),
+			BookTitle:  loan.BookTitle,
+			BookAuthor: loan.BookAuthor,
+			BorrowedAt: loan.BorrowedAt,
+			Status:     loan.Status,
+		}
+		if loan.ReturnedAt != nil {
+			item.ReturnedAt = *loan.ReturnedAt
+		}
+		response.Loans = append(response.Loans, item)
+	}
+	return response, nil
+}
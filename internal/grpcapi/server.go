@@ -0,0 +1,50 @@
+// Package grpcapi menyediakan gRPC API (proto/library/v1) di samping REST API.
+//
+// MENGAPA handler gRPC memanggil service yang sama dengan REST, bukan repository langsung?
+//   - Aturan bisnis (transaksi, locking, kuota, validasi) hanya ditulis sekali di service layer
+//   - Kode ZYD-ERR identik di kedua API, sehingga kiosk dan web katalog membaca error dengan cara yang sama
+package grpcapi
+
+import (
+	"log/slog"
+
+	libraryv1 "github.com/Ar1veeee/library-api/gen/library/v1"
+	"github.com/Ar1veeee/library-api/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Options berisi dependency gRPC server.
+type Options struct {
+	BookService   *service.BookService
+	MemberService *service.MemberService
+	LoanService   *service.LoanService
+
+	// APIKeys adalah key yang boleh memanggil RPC (metadata x-api-key); kosong berarti tanpa autentikasi.
+	APIKeys []string
+	Logger  *slog.Logger
+}
+
+// NewServer membuat gRPC server dengan ketiga service library.v1 dan grpc.health.v1.
+// Health server dikembalikan terpisah agar lifecycle server (drain saat shutdown) bisa mengubah status-nya.
+//
+// Urutan interceptor (luar → dalam): requestContext → accessLog → errorStatus → auth → handler.
+// auth di dalam errorStatus agar penolakan autentikasi juga dikirim sebagai status dengan ErrorInfo ZYD-ERR.
+func NewServer(opts Options) (*grpc.Server, *health.Server) {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		requestContext,
+		accessLog(opts.Logger),
+		errorStatus,
+		auth(opts.APIKeys),
+	))
+
+	libraryv1.RegisterCatalogServiceServer(srv, newCatalogServer(opts.BookService))
+	libraryv1.RegisterMemberServiceServer(srv, newMemberServer(opts.MemberService))
+	libraryv1.RegisterLoanServiceServer(srv, newLoanServer(opts.LoanService))
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	return srv, healthServer
+}
@@ -0,0 +1,93 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/logger"
+	"github.com/Ar1veeee/library-api/internal/metrics"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain adalah ErrorInfo.domain untuk semua kode ZYD-ERR.
+const errorDomain = "library-api"
+
+// grpcCodeFromHTTPStatus memetakan HTTP status di registry ke kode gRPC.
+// MENGAPA dari HTTP status, bukan kolom baru di registry?
+//   - Registry tetap satu sumber kebenaran: kode baru otomatis mendapat kode gRPC yang konsisten dengan REST
+//   - 409 dipetakan ke FailedPrecondition (bukan Aborted) karena stok/kuota habis tidak akan berhasil jika
+//     langsung di-retry, sedangkan Aborted mengundang client gRPC untuk mengulang
+func grpcCodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// toStatus mengubah error menjadi status gRPC dengan details:
+//   - ErrorInfo: reason = kode ZYD-ERR, metadata = trace_id + metadata error (nilai diubah ke string)
+//   - BadRequest: violations validasi (hanya jika ada)
+//   - RequestInfo: request_id = trace_id, agar client bisa melaporkan trace yang sama dengan log server
+//
+// Seperti mapper.HandleHTTPError, status & log berasal dari errors.Resolve: Cause hanya masuk log dan tidak pernah
+// dikirim ke client.
+func toStatus(ctx context.Context, lang i18n.Lang, err error) *status.Status {
+	log := logger.FromContext(ctx)
+
+	apiErr, httpStatus := errors.Resolve(ctx, err)
+	metrics.ObserveAPIError(apiErr.ZiyadErrCode, httpStatus)
+
+	apiErr = apiErr.Localized(lang)
+	st := status.New(grpcCodeFromHTTPStatus(httpStatus), apiErr.Message)
+
+	errorInfo := &errdetails.ErrorInfo{
+		Reason:   apiErr.ZiyadErrCode,
+		Domain:   errorDomain,
+		Metadata: map[string]string{"trace_id": apiErr.TraceID},
+	}
+	for key, value := range apiErr.Metadata {
+		errorInfo.Metadata[key] = fmt.Sprint(value)
+	}
+	details := []protoadapt.MessageV1{errorInfo}
+
+	if len(apiErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range apiErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	details = append(details, &errdetails.RequestInfo{RequestId: apiErr.TraceID})
+
+	withDetails, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		// Gagal menyusun details tidak boleh menghilangkan error aslinya; client tetap menerima kode & pesan.
+		log.Warn("failed to attach gRPC status details", "error", detailErr)
+		return st
+	}
+	return withDetails
+}
@@ -2,12 +2,11 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/Ar1veeee/library-api/internal/dto"
 	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
 	"github.com/Ar1veeee/library-api/internal/service"
-	"github.com/gorilla/mux"
 )
 
 type BookHandler struct {
@@ -21,36 +20,35 @@ func NewBookHandler(bookService *service.BookService) *BookHandler {
 func (h *BookHandler) GetBooks(w http.ResponseWriter, r *http.Request) {
 	books, err := h.bookService.GetAllBooks(r.Context())
 	if err != nil {
-		mapper.HandleHTTPError(w, err)
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
 	response := dto.SuccessResponse{
-		Message: "Berhasil mengambil data buku",
-		Data:    books,
+		MessageID: i18n.MsgBooksListed,
+		Data:      books,
 	}
 
-	mapper.RespondSuccess(w, response, http.StatusOK)
+	mapper.RespondSuccess(w, r, response, http.StatusOK)
 }
 
 func (h *BookHandler) GetBookByID(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	bookID, err := strconv.Atoi(vars["id"])
+	bookID, err := mapper.PathInt(r, "id")
 	if err != nil {
-		mapper.HandleHTTPError(w, err)
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
 	book, err := h.bookService.GetBookByID(r.Context(), bookID)
 	if err != nil {
-		mapper.HandleHTTPError(w, err)
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
 	response := dto.SuccessResponse{
-		Message: "Berhasil mengambil data detail buku",
-		Data:    book,
+		MessageID: i18n.MsgBookDetail,
+		Data:      book,
 	}
 
-	mapper.RespondSuccess(w, response, http.StatusOK)
+	mapper.RespondSuccess(w, r, response, http.StatusOK)
 }
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/openapi"
+	"github.com/gorilla/mux"
+)
+
+// docsContentSecurityPolicy melonggarkan CSP default (default-src 'none') hanya untuk halaman docs:
+// script, style dan fetch openapi.json diizinkan dari origin sendiri, tetap tanpa inline script & tanpa CDN.
+const docsContentSecurityPolicy = "default-src 'none'; script-src 'self'; style-src 'self'; connect-src 'self'; " +
+	"base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+
+type DocsHandler struct {
+	spec []byte
+}
+
+// NewDocsHandler menerima dokumen OpenAPI yang sudah di-encode, karena isinya tetap selama proses berjalan.
+func NewDocsHandler(spec []byte) *DocsHandler {
+	return &DocsHandler{spec: spec}
+}
+
+func (h *DocsHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.spec)
+}
+
+func (h *DocsHandler) Docs(w http.ResponseWriter, r *http.Request) {
+	// Header CSP dari middleware SecurityHeaders ditimpa karena sudah di-set sebelum handler berjalan.
+	w.Header().Set("Content-Security-Policy", docsContentSecurityPolicy)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(openapi.IndexHTML())
+}
+
+func (h *DocsHandler) Asset(w http.ResponseWriter, r *http.Request) {
+	content, contentType, ok := openapi.Asset(mux.Vars(r)["asset"])
+	if !ok {
+		mapper.HandleHTTPError(w, r, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgErrNotFound))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(content)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/gorilla/mux"
+)
+
+// ErrorHandler menyajikan katalog kode ZYD-ERR dari registry di internal/errors,
+// sehingga dokumentasi untuk client tidak bisa tertinggal dari kode yang benar-benar dikembalikan API.
+type ErrorHandler struct{}
+
+func NewErrorHandler() *ErrorHandler {
+	return &ErrorHandler{}
+}
+
+func (h *ErrorHandler) ListErrorCodes(w http.ResponseWriter, r *http.Request) {
+	definitions := errors.Definitions()
+
+	codes := make([]dto.ErrorCodeResponse, len(definitions))
+	for i, definition := range definitions {
+		codes[i] = toErrorCodeResponse(definition)
+	}
+
+	response := dto.SuccessResponse{
+		MessageID: i18n.MsgErrorCodesListed,
+		Data:      codes,
+	}
+
+	mapper.RespondSuccess(w, r, response, http.StatusOK)
+}
+
+// GetErrorCode adalah target URI "type" pada response problem+json (/api/v1/errors/{code}).
+func (h *ErrorHandler) GetErrorCode(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+
+	definition, ok := errors.Lookup(code)
+	if !ok {
+		mapper.HandleHTTPError(w, r, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgErrorCodeNotFound, code))
+		return
+	}
+
+	response := dto.SuccessResponse{
+		MessageID: i18n.MsgErrorCodeDetail,
+		Data:      toErrorCodeResponse(definition),
+	}
+
+	mapper.RespondSuccess(w, r, response, http.StatusOK)
+}
+
+func toErrorCodeResponse(definition errors.Definition) dto.ErrorCodeResponse {
+	messages := make(map[string]string, len(i18n.Supported))
+	for _, lang := range i18n.Supported {
+		messages[string(lang)] = i18n.T(lang, definition.MessageID)
+	}
+
+	return dto.ErrorCodeResponse{
+		Code:        definition.Code,
+		HTTPStatus:  definition.HTTPStatus,
+		Type:        mapper.ProblemType(definition.Code),
+		Description: definition.Description,
+		Messages:    messages,
+	}
+}
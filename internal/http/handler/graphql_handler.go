@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/gqlapi"
+	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+)
+
+type GraphQLHandler struct {
+	server *gqlapi.Server
+}
+
+func NewGraphQLHandler(server *gqlapi.Server) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+// Query menjalankan POST /graphql.
+// Body yang tidak valid (bukan JSON, tanpa "query") dijawab seperti endpoint REST lain: 400 dengan kode ZYD-ERR.
+// Error di dalam query (validasi schema, resolver) tetap 200 dengan field "errors", sesuai konvensi GraphQL.
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "GraphQLHandler.Query")
+	defer span.End()
+
+	var req dto.GraphQLRequest
+	if err := mapper.DecodeJSON(w, r, &req); err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	response := h.server.Execute(ctx, i18n.ForRequest(r), req)
+	mapper.RespondSuccess(w, r, response, http.StatusOK)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/buildinfo"
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/logger"
+	"github.com/Ar1veeee/library-api/internal/migration"
+	"github.com/Ar1veeee/library-api/internal/server"
+)
+
+const serviceName = "library-api"
+
+type HealthHandler struct {
+	db           *sql.DB
+	migrator     *migration.Migrator
+	readiness    *server.Readiness
+	checkTimeout time.Duration
+}
+
+func NewHealthHandler(db *sql.DB, migrator *migration.Migrator, readiness *server.Readiness, checkTimeout time.Duration) *HealthHandler {
+	return &HealthHandler{
+		db:           db,
+		migrator:     migrator,
+		readiness:    readiness,
+		checkTimeout: checkTimeout,
+	}
+}
+
+// Health mempertahankan endpoint lama /api/v1/health untuk client yang sudah memakainya.
+// Hanya mencerminkan status drain (tanpa cek dependency), mengembalikan 503 selama server shutdown.
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	if !h.readiness.IsReady() {
+		mapper.RespondSuccess(w, r, dto.LivenessResponse{Status: "draining", Service: serviceName}, http.StatusServiceUnavailable)
+		return
+	}
+
+	mapper.RespondSuccess(w, r, dto.LivenessResponse{Status: "ok", Service: serviceName}, http.StatusOK)
+}
+
+// Live menjawab "apakah proses masih hidup?".
+// MENGAPA liveness tidak mengecek database?
+// - Jika MySQL down, me-restart container API tidak memperbaiki apa pun dan justru memperparah (restart storm)
+// - Liveness hanya gagal jika proses benar-benar macet, yang ditandai dengan tidak adanya response sama sekali
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	mapper.RespondSuccess(w, r, dto.LivenessResponse{Status: "ok", Service: serviceName}, http.StatusOK)
+}
+
+// Ready menjawab "apakah instance ini boleh menerima traffic?".
+// Mengembalikan 503 jika server sedang drain, database tidak bisa di-ping dalam checkTimeout,
+// atau masih ada migration yang belum diterapkan.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	// Alasan memakai timeout terpisah: probe dari orchestrator biasanya punya timeout pendek,
+	// lebih baik menjawab 503 dengan detail daripada probe timeout tanpa informasi.
+	ctx, cancel := context.WithTimeout(r.Context(), h.checkTimeout)
+	defer cancel()
+
+	response := dto.ReadinessResponse{
+		Status:  "ok",
+		Service: serviceName,
+		Checks:  make(map[string]dto.HealthCheck),
+		Build:   buildinfo.Get(),
+	}
+
+	lang := i18n.ForRequest(r)
+
+	if h.readiness.IsReady() {
+		response.Checks["server"] = dto.HealthCheck{Status: "ok"}
+	} else {
+		response.Checks["server"] = dto.HealthCheck{Status: "fail", Error: i18n.T(lang, i18n.MsgHealthDraining)}
+	}
+
+	start := time.Now()
+	if err := h.db.PingContext(ctx); err != nil {
+		// Detail error (host, user, alamat jaringan) hanya dicatat ke log beserta trace_id;
+		// /readyz bisa diakses tanpa autentikasi, sehingga response cukup berisi pesan generik.
+		logger.FromContext(r.Context()).Warn("readiness check failed", "check", "database", "error", err)
+		response.Checks["database"] = dto.HealthCheck{
+			Status:    "fail",
+			LatencyMs: time.Since(start).Milliseconds(),
+			Error:     i18n.T(lang, i18n.MsgHealthDatabaseUnavailable),
+		}
+	} else {
+		response.Checks["database"] = dto.HealthCheck{
+			Status:    "ok",
+			LatencyMs: time.Since(start).Milliseconds(),
+		}
+	}
+
+	current, latest, pending, err := h.migrator.Version(ctx)
+	response.Migration = dto.MigrationVersion{Current: current, Latest: latest, Pending: pending}
+	switch {
+	case err != nil:
+		logger.FromContext(r.Context()).Warn("readiness check failed", "check", "migrations", "error", err)
+		response.Checks["migrations"] = dto.HealthCheck{Status: "fail", Error: i18n.T(lang, i18n.MsgHealthMigrationsUnknown)}
+	case pending > 0:
+		// Schema tertinggal dari binary: query baru bisa gagal karena kolom/tabel belum ada.
+		response.Checks["migrations"] = dto.HealthCheck{Status: "fail", Error: i18n.T(lang, i18n.MsgHealthPendingMigrations)}
+	default:
+		response.Checks["migrations"] = dto.HealthCheck{Status: "ok"}
+	}
+
+	stats := h.db.Stats()
+	response.Database = dto.DatabasePoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.String(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+
+	statusCode := http.StatusOK
+	for _, check := range response.Checks {
+		if check.Status != "ok" {
+			response.Status = "fail"
+			statusCode = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	mapper.RespondSuccess(w, r, response, statusCode)
+}
@@ -1,12 +1,12 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/Ar1veeee/library-api/internal/dto"
-	"github.com/Ar1veeee/library-api/internal/errors"
 	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
 	"github.com/Ar1veeee/library-api/internal/service"
 )
 
@@ -19,64 +19,52 @@ func NewLoanHandler(loanService *service.LoanService) *LoanHandler {
 }
 
 func (h *LoanHandler) BorrowBook(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LoanHandler.BorrowBook")
+	defer span.End()
+
 	var req dto.BorrowBookRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		mapper.HandleHTTPError(w, err)
+	if err := mapper.DecodeJSON(w, r, &req); err != nil {
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
-	if req.MemberID <= 0 || req.BookID <= 0 {
-		mapper.HandleHTTPError(
-			w,
-			errors.NewAPIError(
-				"member_id dan book_id harus lebih dari 0",
-				errors.ErrCodeInvalidInput,
-			),
-		)
-		return
-	}
+	reqctx.SetMemberID(r.Context(), req.MemberID)
 
-	loanDetail, err := h.loanService.BorrowBook(r.Context(), req.MemberID, req.BookID)
+	loanDetail, err := h.loanService.BorrowBook(ctx, req.MemberID, req.BookID)
 	if err != nil {
-		mapper.HandleHTTPError(w, err)
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
 	response := dto.SuccessResponse{
-		Message: "Buku berhasil dipinjam",
-		Data:    loanDetail,
+		MessageID: i18n.MsgLoanBorrowed,
+		Data:      loanDetail,
 	}
 
-	mapper.RespondSuccess(w, response, http.StatusCreated)
+	mapper.RespondSuccess(w, r, response, http.StatusCreated)
 }
 
 func (h *LoanHandler) ReturnBook(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LoanHandler.ReturnBook")
+	defer span.End()
+
 	var req dto.ReturnBookRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		mapper.HandleHTTPError(w, err)
+	if err := mapper.DecodeJSON(w, r, &req); err != nil {
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
-	if req.MemberID <= 0 || req.BookID <= 0 {
-		mapper.HandleHTTPError(
-			w,
-			errors.NewAPIError(
-				"member_id dan book_id harus lebih dari 0",
-				errors.ErrCodeInvalidInput,
-			),
-		)
-		return
-	}
+	reqctx.SetMemberID(r.Context(), req.MemberID)
 
-	if err := h.loanService.ReturnBook(r.Context(), req.MemberID, req.BookID); err != nil {
-		mapper.HandleHTTPError(w, err)
+	if err := h.loanService.ReturnBook(ctx, req.MemberID, req.BookID); err != nil {
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
 	response := dto.SuccessResponse{
-		Message: "Buku berhasil dikembalikan",
-		Data:    nil,
+		MessageID: i18n.MsgLoanReturned,
+		Data:      nil,
 	}
 
-	mapper.RespondSuccess(w, response, http.StatusOK)
+	mapper.RespondSuccess(w, r, response, http.StatusOK)
 }
@@ -2,12 +2,12 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/Ar1veeee/library-api/internal/dto"
 	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
 	"github.com/Ar1veeee/library-api/internal/service"
-	"github.com/gorilla/mux"
 )
 
 type MemberHandler struct {
@@ -19,23 +19,24 @@ func NewMemberHandler(memberService *service.MemberService) *MemberHandler {
 }
 
 func (h *MemberHandler) GetMemberLoans(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	memberID, err := strconv.Atoi(vars["id"])
+	memberID, err := mapper.PathInt(r, "id")
 	if err != nil {
-		mapper.HandleHTTPError(w, err)
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
+	reqctx.SetMemberID(r.Context(), memberID)
+
 	loans, err := h.memberService.GetMemberLoans(r.Context(), memberID)
 	if err != nil {
-		mapper.HandleHTTPError(w, err)
+		mapper.HandleHTTPError(w, r, err)
 		return
 	}
 
 	response := dto.SuccessResponse{
-		Message: "Berhasil mengambil riwayat peminjaman member",
-		Data:    loans,
+		MessageID: i18n.MsgMemberLoansListed,
+		Data:      loans,
 	}
 
-	mapper.RespondSuccess(w, response, http.StatusOK)
+	mapper.RespondSuccess(w, r, response, http.StatusOK)
 }
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/model"
+	"github.com/Ar1veeee/library-api/internal/service"
+	"github.com/Ar1veeee/library-api/internal/stockstream"
+)
+
+// streamRetry adalah jeda sambung ulang yang disarankan ke EventSource (field "retry"), misalnya saat
+// instance di-restart atau client terlalu lambat dan stream-nya diputus.
+const streamRetry = 3 * time.Second
+
+type StockStreamHandler struct {
+	hub         *stockstream.Hub
+	bookService *service.BookService
+	heartbeat   time.Duration
+}
+
+// NewStockStreamHandler membuat handler SSE. heartbeat adalah jeda komentar ": heartbeat" saat tidak ada
+// perubahan, agar proxy/load balancer tidak menutup koneksi yang dianggap idle.
+func NewStockStreamHandler(hub *stockstream.Hub, bookService *service.BookService, heartbeat time.Duration) *StockStreamHandler {
+	return &StockStreamHandler{hub: hub, bookService: bookService, heartbeat: heartbeat}
+}
+
+// StreamCatalog mengalirkan perubahan stok semua buku.
+func (h *StockStreamHandler) StreamCatalog(w http.ResponseWriter, r *http.Request) {
+	h.stream(w, r, 0)
+}
+
+// StreamBook mengalirkan perubahan stok satu buku; buku yang tidak ada dijawab 404 sebelum stream dimulai.
+func (h *StockStreamHandler) StreamBook(w http.ResponseWriter, r *http.Request) {
+	bookID, err := mapper.PathInt(r, "id")
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	if _, err := h.bookService.GetBookByID(r.Context(), bookID); err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	h.stream(w, r, bookID)
+}
+
+func (h *StockStreamHandler) stream(w http.ResponseWriter, r *http.Request, bookID int) {
+	lastID, resume, err := lastEventID(r)
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	sub, cursor, err := h.hub.Subscribe(ctx, bookID)
+	if err != nil {
+		// Client sudah memutus koneksi sebelum stream siap.
+		return
+	}
+	defer h.hub.Unsubscribe(sub)
+
+	// Stream berumur panjang: deadline baca/tulis server (server.read_timeout/write_timeout) dilepas untuk request ini.
+	// Stream tetap berakhir saat shutdown karena Hub menutup semua Subscription.
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Nginx mem-buffer response secara default; event harus langsung sampai ke client.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds()); err != nil {
+		return
+	}
+
+	send := func(change model.StockChange) error {
+		data, err := json.Marshal(dto.StockChangeEvent{BookID: change.BookID, Stock: change.Stock, Delta: change.Delta})
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: stock\ndata: %s\n\n", change.ID, data); err != nil {
+			return err
+		}
+		lastID = change.ID
+		return nil
+	}
+
+	// Perubahan yang terlewat selama client terputus dibaca dari database, sampai cursor Hub saat Subscribe;
+	// perubahan setelahnya datang lewat Subscription.
+	if resume {
+		if err := h.hub.Replay(ctx, bookID, lastID, cursor, send); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change, ok := <-sub.Changes():
+			if !ok {
+				return
+			}
+			// Instance lain bisa lebih dulu mengirim event yang sama sebelum client pindah ke instance ini.
+			if change.ID <= lastID {
+				continue
+			}
+			if err := send(change); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// lastEventID membaca posisi terakhir client dari header Last-Event-ID (dikirim EventSource saat menyambung ulang)
+// atau query last_event_id (untuk koneksi pertama, karena EventSource tidak bisa menambah header).
+func lastEventID(r *http.Request) (int64, bool, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, false, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false, errors.NewLocalizedError(errors.ErrCodeInvalidInput, i18n.MsgRequestLastEventID).
+			WithViolations(errors.NewViolation("Last-Event-ID", "type", i18n.MsgRequestLastEventID))
+	}
+	return id, true, nil
+}
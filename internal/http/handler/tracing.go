@@ -0,0 +1,6 @@
+package handler
+
+import "github.com/Ar1veeee/library-api/internal/tracing"
+
+// tracer membuat span per handler di bawah span HTTP server dari middleware.Tracing.
+var tracer = tracing.Tracer("github.com/Ar1veeee/library-api/internal/http/handler")
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/service"
+)
+
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWebhookRequest
+	if err := mapper.DecodeJSON(w, r, &req); err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	webhook, err := h.webhookService.CreateSubscription(r.Context(), req)
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	mapper.RespondSuccess(w, r, dto.SuccessResponse{MessageID: i18n.MsgWebhookCreated, Data: webhook}, http.StatusCreated)
+}
+
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	mapper.RespondSuccess(w, r, dto.SuccessResponse{MessageID: i18n.MsgWebhooksListed, Data: webhooks}, http.StatusOK)
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := mapper.PathInt(r, "id")
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(r.Context(), id); err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	mapper.RespondSuccess(w, r, dto.SuccessResponse{MessageID: i18n.MsgWebhookDeleted}, http.StatusOK)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := mapper.PathInt(r, "id")
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), id)
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	mapper.RespondSuccess(w, r, dto.SuccessResponse{MessageID: i18n.MsgWebhookDeliveriesListed, Data: deliveries}, http.StatusOK)
+}
+
+func (h *WebhookHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.webhookService.ListDeadLetters(r.Context())
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	mapper.RespondSuccess(w, r, dto.SuccessResponse{MessageID: i18n.MsgWebhookDeadLettersListed, Data: deliveries}, http.StatusOK)
+}
+
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := mapper.PathInt(r, "id")
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(r.Context(), int64(id))
+	if err != nil {
+		mapper.HandleHTTPError(w, r, err)
+		return
+	}
+
+	mapper.RespondSuccess(w, r, dto.SuccessResponse{MessageID: i18n.MsgWebhookDeliveryRequeued, Data: delivery}, http.StatusOK)
+}
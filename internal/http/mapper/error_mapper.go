@@ -1,47 +1,20 @@
 package mapper
 
 import (
-	"errors"
 	"net/http"
 
 	errorStruct "github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/metrics"
 )
 
-func httpStatusFromAPIError(err errorStruct.APIError) int {
-	switch err.ZiyadErrCode {
-	case errorStruct.ErrCodeNotFound:
-		return http.StatusNotFound
-
-	case errorStruct.ErrCodeInvalidInput:
-		return http.StatusBadRequest
-
-	case errorStruct.ErrCodeAlreadyBorrowed,
-		errorStruct.ErrCodeAlreadyReturned,
-		errorStruct.ErrCodeQuotaExceeded,
-		errorStruct.ErrCodeStockEmpty:
-		return http.StatusConflict
-
-	case errorStruct.ErrCodeTxFailed:
-		return http.StatusInternalServerError
-
-	default:
-		return http.StatusBadRequest
-	}
-}
-
-// MENGAPA menggunakan errors.As?
-// - Kita perlu tahu apakah error dari business logic (APIError)
-// - APIError = user-facing error dengan custom code
-func HandleHTTPError(w http.ResponseWriter, err error) {
-	var apiErr errorStruct.APIError
-	if errors.As(err, &apiErr) {
-		respondError(w, apiErr, httpStatusFromAPIError(apiErr))
-		return
-	}
-
-	respondError(
-		w,
-		errorStruct.NewAPIError("Internal server error", errorStruct.ErrCodeTxFailed),
-		http.StatusInternalServerError,
-	)
+// HandleHTTPError menulis error sebagai response JSON (atau problem+json jika diminta lewat Accept).
+// Fallback pesan generik untuk error yang bukan APIError, status dari registry, dan log Cause ada di
+// errors.Resolve, yang juga dipakai gRPC & GraphQL.
+//
+// TraceID di response diambil dari context request (X-Request-ID dari middleware RequestID),
+// sehingga client bisa melaporkan trace_id yang sama dengan yang tercatat di log server.
+func HandleHTTPError(w http.ResponseWriter, r *http.Request, err error) {
+	apiErr, statusCode := errorStruct.Resolve(r.Context(), err)
+	metrics.ObserveAPIError(apiErr.ZiyadErrCode, statusCode)
+	respondError(w, r, apiErr, statusCode)
 }
@@ -0,0 +1,78 @@
+package mapper
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+)
+
+// ContentTypeProblemJSON adalah media type RFC 7807 untuk error.
+const ContentTypeProblemJSON = "application/problem+json"
+
+// wantsProblemJSON memutuskan format error dari header Accept.
+// MENGAPA default tetap application/json?
+//   - Client lama tidak mengirim Accept (atau mengirim */*) dan sudah mem-parsing ErrorResponse
+//   - problem+json hanya dipilih jika disebut eksplisit dengan q lebih tinggi atau sama dengan application/json,
+//     sehingga gateway/SDK yang memahami problem details bisa ikut tanpa mengubah perilaku client lain
+func wantsProblemJSON(r *http.Request) bool {
+	problemQ, jsonQ := -1.0, -1.0
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+
+			q := 1.0
+			if raw, ok := params["q"]; ok {
+				if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+					q = parsed
+				}
+			}
+
+			switch mediaType {
+			case ContentTypeProblemJSON:
+				problemQ = max(problemQ, q)
+			case "application/json":
+				jsonQ = max(jsonQ, q)
+			}
+		}
+	}
+
+	return problemQ > 0 && problemQ >= jsonQ
+}
+
+// ProblemType adalah URI yang mengidentifikasi jenis error (member "type" RFC 7807).
+// Berupa referensi relatif ke katalog kode error (GET /api/v1/errors/{code}), sehingga tetap valid di host mana pun.
+func ProblemType(code string) string {
+	return "/api/v1/errors/" + code
+}
+
+// problemTitle adalah ringkasan singkat yang sama untuk setiap kemunculan kode error;
+// detail spesifik kejadian ada di member "detail".
+func problemTitle(code string, statusCode int, lang i18n.Lang) string {
+	if definition, ok := errors.Lookup(code); ok {
+		return i18n.T(lang, definition.MessageID)
+	}
+	return http.StatusText(statusCode)
+}
+
+func newProblemDetails(r *http.Request, err errors.APIError, statusCode int, lang i18n.Lang) dto.ProblemDetails {
+	return dto.ProblemDetails{
+		Type:         ProblemType(err.ZiyadErrCode),
+		Title:        problemTitle(err.ZiyadErrCode, statusCode, lang),
+		Status:       statusCode,
+		Detail:       err.Message,
+		Instance:     r.URL.Path,
+		ZiyadErrCode: err.ZiyadErrCode,
+		TraceID:      err.TraceID,
+		Violations:   fieldViolations(err.Violations),
+		Metadata:     err.Metadata,
+	}
+}
@@ -0,0 +1,89 @@
+package mapper
+
+import (
+	"encoding/json"
+	stdErrors "errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/validation"
+	"github.com/gorilla/mux"
+)
+
+// MaxRequestBodyBytes membatasi ukuran body JSON. Request API ini hanya berisi beberapa field,
+// sehingga 1 MB sudah sangat longgar namun mencegah client menghabiskan memori server.
+const MaxRequestBodyBytes = 1 << 20
+
+// DecodeJSON membaca body JSON ke dst lalu menegakkan tag `validate` pada dst.
+// Semua kegagalan (JSON rusak, field tidak dikenal, body terlalu besar, aturan validasi)
+// dikembalikan sebagai APIError ErrCodeInvalidInput, sehingga client mendapat 400 dan bukan 500.
+//
+// MENGAPA field yang tidak dikenal ditolak?
+// - Salah ketik seperti "memberId" dulu diam-diam diabaikan dan berujung pesan "harus lebih dari 0" yang membingungkan
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBodyBytes)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+
+	// Body harus berisi tepat satu objek JSON; sisa data biasanya tanda request yang salah bentuk.
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return errors.NewLocalizedError(errors.ErrCodeInvalidInput, i18n.MsgRequestSingleObject)
+	}
+
+	return validation.Validate(dst)
+}
+
+// decodeError mengubah error encoding/json menjadi APIError; jika field-nya diketahui
+// (tipe salah atau field tidak dikenal), pelanggaran field juga disertakan.
+func decodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	invalid := func(messageID string, args ...any) errors.APIError {
+		return errors.NewLocalizedError(errors.ErrCodeInvalidInput, messageID, args...)
+	}
+
+	switch {
+	case stdErrors.Is(err, io.EOF):
+		return invalid(i18n.MsgRequestBodyEmpty)
+	case stdErrors.Is(err, io.ErrUnexpectedEOF):
+		return invalid(i18n.MsgRequestJSONIncomplete)
+	case stdErrors.As(err, &syntaxErr):
+		return invalid(i18n.MsgRequestJSONSyntax, syntaxErr.Offset)
+	case stdErrors.As(err, &typeErr):
+		args := []any{typeErr.Field, typeErr.Type.String()}
+		return invalid(i18n.MsgRequestFieldType, args...).
+			WithViolations(errors.NewViolation(typeErr.Field, "type", i18n.MsgRequestFieldType, args...))
+	case stdErrors.As(err, &maxBytesErr):
+		return invalid(i18n.MsgRequestBodyTooLarge, maxBytesErr.Limit).
+			WithMetadata("max_body_bytes", maxBytesErr.Limit)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json tidak mengekspos tipe error khusus untuk field yang tidak dikenal.
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return invalid(i18n.MsgRequestUnknownField, field).
+			WithViolations(errors.NewViolation(field, "unknown_field", i18n.MsgRequestUnknownField, field))
+	default:
+		return invalid(i18n.MsgRequestBodyInvalid)
+	}
+}
+
+// PathInt membaca path parameter bilangan bulat positif, misalnya {id} pada /books/{id}.
+// Sebelumnya error strconv diteruskan apa adanya ke HandleHTTPError dan menjadi 500.
+func PathInt(r *http.Request, name string) (int, error) {
+	value, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil || value <= 0 {
+		return 0, errors.NewLocalizedError(errors.ErrCodeInvalidInput, i18n.MsgRequestPathInt, name).
+			WithViolations(errors.NewViolation(name, "gt", i18n.MsgRequestPathInt, name))
+	}
+	return value, nil
+}
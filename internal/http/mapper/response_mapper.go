@@ -6,9 +6,27 @@ import (
 
 	"github.com/Ar1veeee/library-api/internal/dto"
 	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
 )
 
-func respondError(w http.ResponseWriter, err errors.APIError, statusCode int) {
+// respondError menulis error dalam format yang diminta client: ErrorResponse (default) atau problem+json.
+// Pesan diterjemahkan sesuai i18n.ForRequest (Accept-Language, lalu preferensi member).
+func respondError(w http.ResponseWriter, r *http.Request, err errors.APIError, statusCode int) {
+	lang := i18n.ForRequest(r)
+	err = err.Localized(lang)
+
+	// Vary agar cache/gateway tidak menyajikan format atau bahasa yang salah ke client lain.
+	w.Header().Add("Vary", "Accept")
+	w.Header().Add("Vary", "Accept-Language")
+	w.Header().Set("Content-Language", string(lang))
+
+	if wantsProblemJSON(r) {
+		w.Header().Set("Content-Type", ContentTypeProblemJSON)
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(newProblemDetails(r, err, statusCode, lang))
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
@@ -16,10 +34,30 @@ func respondError(w http.ResponseWriter, err errors.APIError, statusCode int) {
 		Message:      err.Message,
 		ZiyadErrCode: err.ZiyadErrCode,
 		TraceID:      err.TraceID,
+		Violations:   fieldViolations(err.Violations),
+		Metadata:     err.Metadata,
 	})
 }
 
-func RespondSuccess(w http.ResponseWriter, data interface{}, statusCode int) {
+func fieldViolations(violations []errors.Violation) []dto.FieldViolation {
+	var result []dto.FieldViolation
+	for _, v := range violations {
+		result = append(result, dto.FieldViolation{Field: v.Field, Rule: v.Rule, Message: v.Message})
+	}
+	return result
+}
+
+// RespondSuccess menulis data sebagai JSON. Jika data berupa dto.SuccessResponse dengan MessageID,
+// Message diisi terjemahan sesuai bahasa request.
+func RespondSuccess(w http.ResponseWriter, r *http.Request, data interface{}, statusCode int) {
+	lang := i18n.ForRequest(r)
+	if response, ok := data.(dto.SuccessResponse); ok && response.MessageID != "" {
+		response.Message = i18n.T(lang, response.MessageID)
+		data = response
+	}
+
+	w.Header().Add("Vary", "Accept-Language")
+	w.Header().Set("Content-Language", string(lang))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
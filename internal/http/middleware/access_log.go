@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+)
+
+// statusRecorder mencatat status code dan jumlah byte yang ditulis handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// statusCode mengembalikan status yang dikirim; handler yang tidak memanggil WriteHeader/Write berarti 200.
+func (r *statusRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Unwrap memungkinkan http.ResponseController mengakses writer asli (misalnya untuk Flush).
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// AccessLog menulis satu baris log terstruktur per request.
+// trace_id, member_id, dan route dibaca dari reqctx.Info, sehingga AccessLog dipasang di dalam RequestID
+// dan di luar MatchRoute.
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			info := reqctx.From(r.Context())
+			if info == nil {
+				info = &reqctx.Info{TraceID: reqctx.GenerateTraceID()}
+				r = r.WithContext(reqctx.With(r.Context(), info))
+			}
+
+			recorder := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+
+			status := recorder.statusCode()
+			attrs := []slog.Attr{
+				slog.String("trace_id", info.TraceID),
+				slog.String("method", r.Method),
+				slog.String("route", routeFrom(r)),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int("bytes", recorder.bytes),
+				slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+				slog.String("remote_addr", r.RemoteAddr),
+			}
+			if info.MemberID > 0 {
+				attrs = append(attrs, slog.Int("member_id", info.MemberID))
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+
+			logger.LogAttrs(r.Context(), level, "http request", attrs...)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/apikey"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+)
+
+// RequireAPIKey menolak request tanpa header X-API-Key yang terdaftar dengan 401 (ZYD-ERR-009).
+// Daftar key kosong berarti tanpa autentikasi; Validate hanya mengizinkannya di luar production.
+func RequireAPIKey(apiKeys []string) func(http.Handler) http.Handler {
+	keys := apikey.New(apiKeys)
+
+	return func(next http.Handler) http.Handler {
+		if keys.Empty() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !keys.Valid(r.Header.Get(APIKeyHeader)) {
+				mapper.HandleHTTPError(w, r, errors.NewLocalizedError(errors.ErrCodeUnauthenticated, i18n.MsgErrUnauthenticated))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
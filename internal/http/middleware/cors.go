@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions mengatur origin dan header yang boleh dipakai browser dari origin lain (misalnya web katalog).
+type CORSOptions struct {
+	// AllowedOrigins berisi origin lengkap (https://katalog.example.ac.id) atau "*" untuk semua origin.
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	// ExposedHeaders adalah header response yang boleh dibaca JavaScript (misalnya X-Request-ID, RateLimit-*).
+	ExposedHeaders []string
+	// AllowCredentials mengizinkan cookie/Authorization; diabaikan jika origin "*" karena dilarang spesifikasi CORS.
+	AllowCredentials bool
+	// MaxAge adalah lama browser boleh meng-cache hasil preflight.
+	MaxAge time.Duration
+}
+
+// CORS menjawab preflight (OPTIONS) dan menambahkan header Access-Control-* untuk origin yang diizinkan.
+// MENGAPA origin yang tidak diizinkan tetap diteruskan ke handler?
+// - CORS ditegakkan oleh browser, bukan server: tanpa header Allow-Origin browser menolak membaca response
+// - Client non-browser (kiosk, curl) tidak mengirim Origin dan tidak boleh ikut terblokir
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	allowAll := false
+	origins := make(map[string]struct{}, len(opts.AllowedOrigins))
+	for _, origin := range opts.AllowedOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+		origins[strings.TrimSuffix(origin, "/")] = struct{}{}
+	}
+
+	allowCredentials := opts.AllowCredentials && !allowAll
+	allowedMethods := strings.Join(opts.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(opts.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(opts.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			// Vary: Origin wajib agar cache/CDN tidak menyajikan response origin A ke origin B.
+			header.Add("Vary", "Origin")
+
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if _, ok := origins[origin]; !ok && !allowAll {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowAll {
+				header.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				header.Set("Access-Control-Allow-Origin", origin)
+			}
+			if allowCredentials {
+				header.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				header.Add("Vary", "Access-Control-Request-Method")
+				header.Add("Vary", "Access-Control-Request-Headers")
+				header.Set("Access-Control-Allow-Methods", allowedMethods)
+				header.Set("Access-Control-Allow-Headers", allowedHeaders)
+				if opts.MaxAge > 0 {
+					header.Set("Access-Control-Max-Age", maxAge)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if exposedHeaders != "" {
+				header.Set("Access-Control-Expose-Headers", exposedHeaders)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/metrics"
+)
+
+// Metrics mencatat jumlah request dan latency per route ke Prometheus.
+// Route dibaca dari reqctx.Info, sehingga harus dipasang di luar MatchRoute.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+
+		metrics.ObserveHTTPRequest(r.Method, routeFrom(r), recorder.statusCode(), time.Since(start))
+	})
+}
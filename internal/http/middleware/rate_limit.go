@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/http/mapper"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/ratelimit"
+)
+
+// APIKeyHeader adalah header yang dipakai integrator (kiosk, portal) untuk mengidentifikasi dirinya.
+const APIKeyHeader = "X-API-Key"
+
+// RateLimitOptions mengatur limiter untuk request baca dan tulis.
+type RateLimitOptions struct {
+	// Read dipakai untuk GET/HEAD, Write untuk method lain (borrow, return).
+	Read  *ratelimit.Limiter
+	Write *ratelimit.Limiter
+	// ReadOnlyPaths adalah path yang memakai limiter Read untuk semua method,
+	// misalnya /graphql yang hanya berisi query tetapi dikirim dengan POST.
+	ReadOnlyPaths []string
+	// APIKeys adalah daftar API key yang dikenal; key lain diperlakukan seperti client tanpa key.
+	APIKeys []string
+	// TrustProxy membaca IP client dari X-Forwarded-For / X-Real-IP (hanya aktifkan di belakang proxy tepercaya).
+	TrustProxy bool
+}
+
+// RateLimit membatasi jumlah request per client dengan token bucket dan mengirim header
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset (serta Retry-After saat ditolak).
+// MENGAPA limit tulis dipisah dari limit baca?
+// - Borrow/return mengambil lock FOR UPDATE dan membuka transaksi, jauh lebih mahal daripada GET katalog
+// - Client yang rajin membaca katalog tidak boleh kehabisan kuota untuk meminjam, dan sebaliknya
+func RateLimit(opts RateLimitOptions) func(http.Handler) http.Handler {
+	apiKeys := make(map[string]struct{}, len(opts.APIKeys))
+	for _, key := range opts.APIKeys {
+		apiKeys[key] = struct{}{}
+	}
+
+	readOnlyPaths := make(map[string]struct{}, len(opts.ReadOnlyPaths))
+	for _, path := range opts.ReadOnlyPaths {
+		readOnlyPaths[path] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limiter := opts.Write
+			_, readOnly := readOnlyPaths[r.URL.Path]
+			if readOnly || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				limiter = opts.Read
+			}
+
+			result := limiter.Allow(clientKey(r, apiKeys, opts.TrustProxy))
+
+			header := w.Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				retryAfter := ceilSeconds(result.RetryAfter)
+				header.Set("Retry-After", strconv.Itoa(retryAfter))
+				mapper.HandleHTTPError(w, r, errors.NewLocalizedError(
+					errors.ErrCodeRateLimited,
+					i18n.MsgRateLimitExceeded,
+					retryAfter,
+				).WithMetadata("retry_after_seconds", retryAfter))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientKey menentukan bucket milik request: API key yang dikenal, atau IP client.
+// Member belum bisa dipakai sebagai key karena API belum memiliki autentikasi member
+// (member_id di body bisa diisi bebas oleh client); setelah ada, key "member:<id>" diletakkan paling depan di sini.
+func clientKey(r *http.Request, apiKeys map[string]struct{}, trustProxy bool) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		if _, ok := apiKeys[key]; ok {
+			return "apikey:" + key
+		}
+	}
+
+	return "ip:" + clientIP(r, trustProxy)
+}
+
+// clientIP mengembalikan IP client. Dengan trustProxy, IP diambil dari entry X-Forwarded-For paling kanan
+// (atau X-Real-IP), yaitu alamat yang ditambahkan proxy tepercaya di depan API.
+//
+// MENGAPA bukan entry paling kiri?
+//   - Client bebas mengirim X-Forwarded-For sendiri; proxy hanya menambahkan alamat peer-nya di ujung kanan
+//   - Entry paling kiri bisa diganti setiap request, sehingga satu client bisa mendapat bucket baru terus-menerus
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+			forwarded := values[len(values)-1]
+			if i := strings.LastIndex(forwarded, ","); i >= 0 {
+				forwarded = forwarded[i+1:]
+			}
+			if ip := strings.TrimSpace(forwarded); ip != "" {
+				return ip
+			}
+		}
+		if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+			return realIP
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ceilSeconds membulatkan ke atas agar client tidak mencoba ulang sedikit terlalu cepat.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
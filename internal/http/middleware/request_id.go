@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+)
+
+// RequestIDHeader adalah header yang dipakai untuk menerima dan mengembalikan ID request.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength membatasi ID dari client agar tidak bisa dipakai untuk membanjiri log.
+const maxRequestIDLength = 128
+
+// RequestID menerima X-Request-ID dari client (misalnya dari API gateway) atau membuat ID baru,
+// menyimpannya di context, dan mengembalikannya di setiap response.
+// MENGAPA menerima ID dari client?
+// - Gateway/service pemanggil bisa menelusuri satu request lintas service dengan ID yang sama
+// - ID yang tidak valid diganti ID baru, bukan ditolak, agar request tetap dilayani
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = reqctx.GenerateTraceID()
+		}
+
+		// Header di-set sebelum handler berjalan agar ikut terkirim walaupun handler langsung menulis body.
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := reqctx.With(r.Context(), &reqctx.Info{TraceID: id})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// validRequestID hanya mengizinkan karakter aman untuk log & header (alfanumerik, '-', '_', '.', ':').
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
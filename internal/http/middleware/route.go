@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+	"github.com/gorilla/mux"
+)
+
+// unmatchedRoute dipakai untuk request yang tidak cocok dengan route mana pun (404/405).
+const unmatchedRoute = "unmatched"
+
+// MatchRoute mencari route template yang cocok, menyimpannya di reqctx.Info, lalu meneruskan ke router.
+// MENGAPA dicari di luar router?
+//   - Middleware mux (router.Use) hanya jalan untuk route yang cocok, sehingga 404/405 tidak tercatat
+//   - Context yang diisi mux tidak terlihat oleh middleware di luar router, sedangkan reqctx.Info bisa
+//
+// Alasan memakai template bukan URL asli: cardinality rendah sehingga log & metric mudah di-aggregate per endpoint.
+func MatchRoute(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if info := reqctx.From(r.Context()); info != nil {
+			info.Route = unmatchedRoute
+
+			var match mux.RouteMatch
+			if router.Match(r, &match) && match.Route != nil {
+				if template, err := match.Route.GetPathTemplate(); err == nil {
+					info.Route = template
+				}
+			}
+		}
+
+		router.ServeHTTP(w, r)
+	})
+}
+
+// routeFrom mengembalikan route template dari context request.
+func routeFrom(r *http.Request) string {
+	if info := reqctx.From(r.Context()); info != nil && info.Route != "" {
+		return info.Route
+	}
+	return unmatchedRoute
+}
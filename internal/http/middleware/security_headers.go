@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SecurityHeaders menambahkan header keamanan standar ke setiap response.
+// API hanya mengembalikan JSON, sehingga kebijakannya ketat: tidak boleh di-frame,
+// tidak boleh memuat resource apa pun, dan browser tidak boleh menebak content type.
+// Satu-satunya pengecualian adalah halaman docs UI (handler.DocsHandler.Docs) yang menimpa CSP-nya sendiri.
+//
+// hstsMaxAge 0 berarti Strict-Transport-Security tidak dikirim.
+// Alasan default mati: HSTS di HTTP polos (development) diabaikan browser, dan jika TLS di-terminate
+// oleh proxy, header ini sebaiknya diputuskan oleh operator yang tahu domain-nya sudah full HTTPS.
+func SecurityHeaders(hstsMaxAge time.Duration) func(http.Handler) http.Handler {
+	hsts := ""
+	if hstsMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(hstsMaxAge.Seconds())) + "; includeSubDomains"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Set("X-Content-Type-Options", "nosniff")
+			header.Set("X-Frame-Options", "DENY")
+			header.Set("Referrer-Policy", "no-referrer")
+			header.Set("Content-Security-Policy", "default-src 'none'; frame-ancestors 'none'")
+			if hsts != "" {
+				header.Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+	"github.com/Ar1veeee/library-api/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/Ar1veeee/library-api/internal/http")
+
+// Tracing membuat server span untuk setiap request dan melanjutkan trace dari header W3C
+// (traceparent/tracestate) jika pemanggil mengirimnya.
+// Nama span memakai route template dari reqctx.Info, sehingga harus dipasang di luar MatchRoute.
+func Tracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		ctx, span := tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				attribute.String("http.request_id", reqctx.TraceID(r.Context())),
+			),
+		)
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		route := routeFrom(r)
+		status := recorder.statusCode()
+
+		// Nama span diperbarui setelah routing, contoh: "POST /api/v1/borrow".
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+		if info := reqctx.From(r.Context()); info != nil && info.MemberID > 0 {
+			span.SetAttributes(attribute.Int("member.id", info.MemberID))
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
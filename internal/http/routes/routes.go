@@ -4,29 +4,70 @@ import (
 	"net/http"
 
 	handler2 "github.com/Ar1veeee/library-api/internal/http/handler"
+	"github.com/Ar1veeee/library-api/internal/metrics"
 	"github.com/gorilla/mux"
 )
 
-func RegisterRoutes(router *mux.Router, bookHandler *handler2.BookHandler, memberHandler *handler2.MemberHandler, loanHandler *handler2.LoanHandler) {
+func RegisterRoutes(router *mux.Router, healthHandler *handler2.HealthHandler, bookHandler *handler2.BookHandler, memberHandler *handler2.MemberHandler, loanHandler *handler2.LoanHandler, errorHandler *handler2.ErrorHandler, docsHandler *handler2.DocsHandler, graphqlHandler *handler2.GraphQLHandler, stockStreamHandler *handler2.StockStreamHandler, webhookHandler *handler2.WebhookHandler, webhookAuth mux.MiddlewareFunc, apiMiddlewares ...mux.MiddlewareFunc) {
+	// Probe untuk orchestrator/load balancer, di luar /api/v1 karena bukan bagian dari kontrak API
+	router.HandleFunc("/livez", healthHandler.Live).Methods("GET")
+	router.HandleFunc("/readyz", healthHandler.Ready).Methods("GET")
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
+
 	api := router.PathPrefix("/api/v1").Subrouter()
 
+	// Middleware khusus API (misalnya rate limit) tidak berlaku untuk probe dan /metrics di atas
+	api.Use(apiMiddlewares...)
+
 	// Health check
-	api.HandleFunc("/health", healthHandler).Methods("GET")
+	api.HandleFunc("/health", healthHandler.Health).Methods("GET")
 
 	// Loan
 	api.HandleFunc("/borrow", loanHandler.BorrowBook).Methods("POST")
 	api.HandleFunc("/return", loanHandler.ReturnBook).Methods("POST")
 
 	// Books
+	// /books/stream didaftarkan sebelum {id} agar tidak tertangkap sebagai ID.
 	api.HandleFunc("/books", bookHandler.GetBooks).Methods("GET")
+	api.HandleFunc("/books/stream", stockStreamHandler.StreamCatalog).Methods("GET")
 	api.HandleFunc("/books/{id}", bookHandler.GetBookByID).Methods("GET")
+	api.HandleFunc("/books/{id}/stream", stockStreamHandler.StreamBook).Methods("GET")
 
 	// Members
 	api.HandleFunc("/members/{id}/loans", memberHandler.GetMemberLoans).Methods("GET")
-}
 
-func healthHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`{"status": "ok", "service": "library-api"}`))
+	// Webhook integrator; dilindungi API key karena subscription menentukan ke mana data peminjaman dikirim.
+	// dead-letters didaftarkan sebelum {id} agar tidak tertangkap sebagai ID.
+	webhooks := api.PathPrefix("/webhooks").Subrouter()
+	webhooks.Use(webhookAuth)
+	webhooks.HandleFunc("", webhookHandler.CreateWebhook).Methods("POST")
+	webhooks.HandleFunc("", webhookHandler.ListWebhooks).Methods("GET")
+	webhooks.HandleFunc("/dead-letters", webhookHandler.ListDeadLetters).Methods("GET")
+	webhooks.HandleFunc("/deliveries/{id}/retry", webhookHandler.RetryDelivery).Methods("POST")
+	webhooks.HandleFunc("/{id}", webhookHandler.DeleteWebhook).Methods("DELETE")
+	webhooks.HandleFunc("/{id}/deliveries", webhookHandler.ListDeliveries).Methods("GET")
+
+	// Katalog kode error (juga target URI "type" pada problem+json)
+	api.HandleFunc("/errors", errorHandler.ListErrorCodes).Methods("GET")
+	api.HandleFunc("/errors/{code}", errorHandler.GetErrorCode).Methods("GET")
+
+	// Dokumentasi API; setiap route di file ini wajib juga ada di internal/openapi/operations.go (dicek saat startup)
+	api.HandleFunc("/openapi.json", docsHandler.OpenAPI).Methods("GET")
+	api.HandleFunc("/docs", docsHandler.Docs).Methods("GET")
+	api.HandleFunc("/docs/{asset}", docsHandler.Asset).Methods("GET")
+
+	// GraphQL untuk dashboard member portal. Di luar /api/v1 (path standar GraphQL),
+	// tetapi memakai middleware API yang sama agar CORS dan rate limit tetap berlaku.
+	graphql := router.Path("/graphql").Subrouter()
+	graphql.Use(apiMiddlewares...)
+	graphql.Methods("POST").HandlerFunc(graphqlHandler.Query)
+	graphql.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Preflight CORS: route OPTIONS untuk semua path /api/v1 agar middleware subrouter (CORS) ikut berjalan.
+	// Tanpa route ini mux langsung menjawab 405 karena route di atas hanya menerima GET/POST.
+	api.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
 }
@@ -0,0 +1,149 @@
+package i18n
+
+// Message ID untuk semua pesan yang bisa sampai ke client.
+// MENGAPA konstanta, bukan string langsung di pemanggil?
+//   - Salah ketik ketahuan saat compile, bukan sebagai pesan "loan.borowed" di response production
+const (
+	// Pesan default per kode error ZYD-ERR, dipakai jika error tidak membawa message ID sendiri.
+	MsgErrStockEmpty      = "error.stock_empty"
+	MsgErrQuotaExceeded   = "error.quota_exceeded"
+	MsgErrAlreadyBorrowed = "error.already_borrowed"
+	MsgErrTxFailed        = "error.tx_failed"
+	MsgErrNotFound        = "error.not_found"
+	MsgErrInvalidInput    = "error.invalid_input"
+	MsgErrAlreadyReturned = "error.already_returned"
+	MsgErrRateLimited     = "error.rate_limited"
+	MsgErrUnauthenticated = "error.unauthenticated"
+	MsgErrInternal        = "error.internal"
+
+	MsgRequestBodyEmpty      = "request.body_empty"
+	MsgRequestJSONIncomplete = "request.json_incomplete"
+	MsgRequestJSONSyntax     = "request.json_syntax"
+	MsgRequestFieldType      = "request.field_type"
+	MsgRequestBodyTooLarge   = "request.body_too_large"
+	MsgRequestUnknownField   = "request.unknown_field"
+	MsgRequestBodyInvalid    = "request.body_invalid"
+	MsgRequestSingleObject   = "request.single_object"
+	MsgRequestPathInt        = "request.path_int"
+	MsgRequestLastEventID    = "request.last_event_id"
+
+	MsgValidationFailed   = "validation.failed"
+	MsgValidationRequired = "validation.required"
+	MsgValidationGT       = "validation.gt"
+	MsgValidationMin      = "validation.min"
+	MsgValidationMax      = "validation.max"
+	MsgValidationEmail    = "validation.email"
+	MsgValidationOneOf    = "validation.oneof"
+	MsgValidationURL      = "validation.url"
+	MsgValidationInvalid  = "validation.invalid"
+
+	MsgRateLimitExceeded = "rate_limit.exceeded"
+	MsgTxBeginFailed     = "db.tx_begin_failed"
+
+	MsgBookNotFound            = "book.not_found"
+	MsgBookOutOfStock          = "book.out_of_stock"
+	MsgMemberNotFound          = "member.not_found"
+	MsgMemberEmailTaken        = "member.email_taken"
+	MsgLoanNotFound            = "loan.not_found"
+	MsgLoanQuotaExceeded       = "loan.quota_exceeded"
+	MsgLoanAlreadyBorrowed     = "loan.already_borrowed"
+	MsgLoanNotBorrowed         = "loan.not_borrowed"
+	MsgLoanAlreadyReturned     = "loan.already_returned"
+	MsgBooksListed             = "book.listed"
+	MsgBookDetail              = "book.detail"
+	MsgMemberLoansListed       = "member.loans_listed"
+	MsgLoanBorrowed            = "loan.borrowed"
+	MsgLoanReturned            = "loan.returned"
+	MsgErrorCodesListed        = "error_code.listed"
+	MsgErrorCodeDetail         = "error_code.detail"
+	MsgErrorCodeNotFound       = "error_code.not_found"
+	MsgHealthDraining          = "health.draining"
+	MsgHealthPendingMigrations = "health.pending_migrations"
+
+	MsgHealthDatabaseUnavailable = "health.database_unavailable"
+	MsgHealthMigrationsUnknown   = "health.migrations_unknown"
+
+	MsgWebhookCreated            = "webhook.created"
+	MsgWebhooksListed            = "webhook.listed"
+	MsgWebhookDeleted            = "webhook.deleted"
+	MsgWebhookNotFound           = "webhook.not_found"
+	MsgWebhookDeliveriesListed   = "webhook.deliveries_listed"
+	MsgWebhookDeadLettersListed  = "webhook.dead_letters_listed"
+	MsgWebhookDeliveryRequeued   = "webhook.delivery_requeued"
+	MsgWebhookDeadLetterNotFound = "webhook.dead_letter_not_found"
+	MsgWebhookURLPrivate         = "webhook.url_private"
+	MsgWebhookURLUnresolvable    = "webhook.url_unresolvable"
+)
+
+// catalog memetakan message ID ke format string per bahasa (verb fmt untuk argumen).
+// Setiap entry wajib punya terjemahan Default; bahasa lain yang kosong jatuh ke Default.
+var catalog = map[string]map[Lang]string{
+	MsgErrStockEmpty:      {ID: "Stok buku habis", EN: "Book is out of stock"},
+	MsgErrQuotaExceeded:   {ID: "Kuota pinjam member habis", EN: "Member loan quota exceeded"},
+	MsgErrAlreadyBorrowed: {ID: "Buku sedang dipinjam member", EN: "Book is already borrowed by the member"},
+	MsgErrTxFailed:        {ID: "Gagal memproses transaksi database", EN: "Database transaction failed"},
+	MsgErrNotFound:        {ID: "Data tidak ditemukan", EN: "Resource not found"},
+	MsgErrInvalidInput:    {ID: "Request tidak valid", EN: "Invalid request"},
+	MsgErrAlreadyReturned: {ID: "Buku sudah dikembalikan", EN: "Book has already been returned"},
+	MsgErrRateLimited:     {ID: "Terlalu banyak request", EN: "Too many requests"},
+	MsgErrUnauthenticated: {ID: "API key tidak valid", EN: "Invalid API key"},
+	MsgErrInternal:        {ID: "Internal server error", EN: "Internal server error"},
+
+	MsgRequestBodyEmpty:      {ID: "Body request kosong", EN: "Request body is empty"},
+	MsgRequestJSONIncomplete: {ID: "Format JSON tidak lengkap", EN: "Incomplete JSON"},
+	MsgRequestJSONSyntax:     {ID: "Format JSON tidak valid pada posisi %d", EN: "Invalid JSON at position %d"},
+	MsgRequestFieldType:      {ID: "Field %s harus bertipe %s", EN: "Field %s must be of type %s"},
+	MsgRequestBodyTooLarge:   {ID: "Body request melebihi batas %d byte", EN: "Request body exceeds the %d byte limit"},
+	MsgRequestUnknownField:   {ID: "Field %s tidak dikenal", EN: "Unknown field %s"},
+	MsgRequestBodyInvalid:    {ID: "Body request tidak valid", EN: "Invalid request body"},
+	MsgRequestSingleObject:   {ID: "Body request harus berisi satu objek JSON", EN: "Request body must contain a single JSON object"},
+	MsgRequestPathInt:        {ID: "%s harus berupa angka lebih dari 0", EN: "%s must be a number greater than 0"},
+	MsgRequestLastEventID:    {ID: "Last-Event-ID harus berupa ID event dari stream ini", EN: "Last-Event-ID must be an event ID from this stream"},
+
+	MsgValidationFailed:   {ID: "Validasi gagal: %s", EN: "Validation failed: %s"},
+	MsgValidationRequired: {ID: "%s wajib diisi", EN: "%s is required"},
+	MsgValidationGT:       {ID: "%s harus lebih dari %s", EN: "%s must be greater than %s"},
+	MsgValidationMin:      {ID: "%s minimal %s", EN: "%s must be at least %s"},
+	MsgValidationMax:      {ID: "%s maksimal %s", EN: "%s must be at most %s"},
+	MsgValidationEmail:    {ID: "%s harus berupa alamat email yang valid", EN: "%s must be a valid email address"},
+	MsgValidationOneOf:    {ID: "%s harus salah satu dari: %s", EN: "%s must be one of: %s"},
+	MsgValidationURL:      {ID: "%s harus berupa URL http atau https", EN: "%s must be an http or https URL"},
+	MsgValidationInvalid:  {ID: "%s tidak valid (%s)", EN: "%s is invalid (%s)"},
+
+	MsgRateLimitExceeded: {ID: "Terlalu banyak request, coba lagi dalam %d detik", EN: "Too many requests, try again in %d seconds"},
+	MsgTxBeginFailed:     {ID: "Gagal memulai transaksi database", EN: "Failed to start database transaction"},
+
+	MsgBookNotFound:            {ID: "Buku tidak ditemukan", EN: "Book not found"},
+	MsgBookOutOfStock:          {ID: "Stok buku habis", EN: "Book is out of stock"},
+	MsgMemberNotFound:          {ID: "Member tidak ditemukan", EN: "Member not found"},
+	MsgMemberEmailTaken:        {ID: "Email sudah terdaftar", EN: "Email is already registered"},
+	MsgLoanNotFound:            {ID: "Peminjaman tidak ditemukan", EN: "Loan not found"},
+	MsgLoanQuotaExceeded:       {ID: "Member sudah mencapai batas pinjam maksimal yaitu %d buku", EN: "Member has reached the maximum of %d borrowed books"},
+	MsgLoanAlreadyBorrowed:     {ID: "Anda sedang meminjam buku ini", EN: "You are already borrowing this book"},
+	MsgLoanNotBorrowed:         {ID: "Anda tidak sedang meminjam buku ini", EN: "You are not borrowing this book"},
+	MsgLoanAlreadyReturned:     {ID: "Buku sudah dikembalikan", EN: "Book has already been returned"},
+	MsgBooksListed:             {ID: "Berhasil mengambil data buku", EN: "Books retrieved successfully"},
+	MsgBookDetail:              {ID: "Berhasil mengambil data detail buku", EN: "Book details retrieved successfully"},
+	MsgMemberLoansListed:       {ID: "Berhasil mengambil riwayat peminjaman member", EN: "Member loan history retrieved successfully"},
+	MsgLoanBorrowed:            {ID: "Buku berhasil dipinjam", EN: "Book borrowed successfully"},
+	MsgLoanReturned:            {ID: "Buku berhasil dikembalikan", EN: "Book returned successfully"},
+	MsgErrorCodesListed:        {ID: "Berhasil mengambil daftar kode error", EN: "Error codes retrieved successfully"},
+	MsgErrorCodeDetail:         {ID: "Berhasil mengambil detail kode error", EN: "Error code details retrieved successfully"},
+	MsgErrorCodeNotFound:       {ID: "Kode error %s tidak ditemukan", EN: "Error code %s not found"},
+	MsgHealthDraining:          {ID: "Server sedang drain", EN: "server is draining"},
+	MsgHealthPendingMigrations: {ID: "Masih ada migration yang belum diterapkan", EN: "pending migrations"},
+
+	MsgHealthDatabaseUnavailable: {ID: "Database tidak dapat dihubungi", EN: "database unavailable"},
+	MsgHealthMigrationsUnknown:   {ID: "Versi migration tidak dapat dibaca", EN: "migration version unavailable"},
+
+	MsgWebhookCreated:            {ID: "Webhook berhasil didaftarkan", EN: "Webhook registered successfully"},
+	MsgWebhooksListed:            {ID: "Berhasil mengambil daftar webhook", EN: "Webhooks retrieved successfully"},
+	MsgWebhookDeleted:            {ID: "Webhook berhasil dinonaktifkan", EN: "Webhook deactivated successfully"},
+	MsgWebhookNotFound:           {ID: "Webhook tidak ditemukan", EN: "Webhook not found"},
+	MsgWebhookDeliveriesListed:   {ID: "Berhasil mengambil log pengiriman webhook", EN: "Webhook delivery log retrieved successfully"},
+	MsgWebhookDeadLettersListed:  {ID: "Berhasil mengambil daftar dead-letter webhook", EN: "Webhook dead letters retrieved successfully"},
+	MsgWebhookDeliveryRequeued:   {ID: "Pengiriman webhook dijadwalkan ulang", EN: "Webhook delivery requeued"},
+	MsgWebhookDeadLetterNotFound: {ID: "Pengiriman webhook tidak ada di dead-letter", EN: "Webhook delivery is not in the dead-letter list"},
+	MsgWebhookURLPrivate:         {ID: "%s tidak boleh mengarah ke alamat loopback, link-local, atau private", EN: "%s must not point to a loopback, link-local, or private address"},
+	MsgWebhookURLUnresolvable:    {ID: "Host pada %s tidak dapat di-resolve", EN: "The host in %s cannot be resolved"},
+}
@@ -0,0 +1,123 @@
+// Package i18n menerjemahkan pesan yang ditampilkan ke client (error & success) ke bahasa Indonesia atau Inggris.
+//
+// MENGAPA terjemahan dilakukan saat response ditulis, bukan di service?
+//   - Service & repository tetap bebas dari urusan HTTP (Accept-Language); mereka cukup mengisi message ID + argumen
+//   - Preferensi bahasa member baru diketahui setelah service membaca data member, sedangkan response ditulis paling akhir
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+)
+
+// Lang adalah kode bahasa ISO 639-1 yang didukung.
+type Lang string
+
+const (
+	ID Lang = "id"
+	EN Lang = "en"
+
+	// Default dipakai jika client tidak meminta bahasa tertentu; API ini sejak awal berbahasa Indonesia.
+	Default = ID
+)
+
+// Supported berisi semua bahasa yang punya terjemahan lengkap di catalog.
+var Supported = []Lang{ID, EN}
+
+// Parse mengembalikan Lang untuk kode seperti "en", "EN" atau "en-US"; ok=false jika tidak didukung.
+func Parse(code string) (Lang, bool) {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(code)), "-")
+	for _, lang := range Supported {
+		if string(lang) == base {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// T menerjemahkan message ID ke bahasa lang, mengisi argumen dengan fmt.Sprintf.
+// Jika terjemahan untuk lang tidak ada, dipakai Default; jika message ID tidak dikenal, ID itu sendiri dikembalikan
+// agar kesalahan catalog terlihat jelas tanpa membuat request gagal.
+func T(lang Lang, id string, args ...any) string {
+	translations, ok := catalog[id]
+	if !ok {
+		return id
+	}
+
+	format, ok := translations[lang]
+	if !ok {
+		format = translations[Default]
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Negotiate memilih bahasa terbaik dari header Accept-Language (RFC 9110), misalnya "en-US,en;q=0.9,id;q=0.8".
+// Mengembalikan string kosong jika header kosong atau tidak ada bahasa yang didukung,
+// sehingga pemanggil bisa jatuh ke preferensi member lalu Default.
+func Negotiate(acceptLanguage string) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		q := 1.0
+		if name, value, found := strings.Cut(strings.TrimSpace(params), "="); found && strings.TrimSpace(name) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		// "*" sengaja diabaikan: artinya "bahasa apa saja", jadi preferensi member/Default yang menentukan.
+		if lang, ok := Parse(tag); ok {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	// Stable agar urutan di header menjadi tie-breaker untuk q yang sama.
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+// ForRequest menentukan bahasa response dengan urutan prioritas:
+//  1. Header Accept-Language yang berisi bahasa yang didukung
+//  2. Preferensi bahasa member yang dilayani request (reqctx.SetMemberLanguage, diisi service)
+//  3. Default
+func ForRequest(r *http.Request) Lang {
+	return Resolve(r.Context(), strings.Join(r.Header.Values("Accept-Language"), ","))
+}
+
+// Resolve sama dengan ForRequest untuk transport selain HTTP (misalnya metadata "accept-language" gRPC).
+func Resolve(ctx context.Context, acceptLanguage string) Lang {
+	if lang := Negotiate(acceptLanguage); lang != "" {
+		return lang
+	}
+	if info := reqctx.From(ctx); info != nil {
+		if lang, ok := Parse(info.MemberLanguage); ok {
+			return lang
+		}
+	}
+	return Default
+}
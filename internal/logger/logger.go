@@ -0,0 +1,49 @@
+// Package logger menyiapkan structured logging (log/slog) untuk seluruh aplikasi.
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+)
+
+// New membuat logger dengan format "json" (default, untuk log aggregator) atau "text" (untuk development).
+func New(w io.Writer, level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(level)}
+
+	var handler slog.Handler
+	if strings.EqualFold(format, "text") {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(handler).With("service", "library-api")
+}
+
+// FromContext mengembalikan logger default yang sudah diberi trace_id dari request (jika ada).
+// Alasan: setiap baris log yang ditulis selama request bisa dikorelasikan dengan access log
+// dan dengan trace_id yang dikembalikan ke client di error response.
+func FromContext(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+	if traceID := reqctx.TraceID(ctx); traceID != "" {
+		logger = logger.With("trace_id", traceID)
+	}
+	return logger
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// inventoryQueryTimeout membatasi query gauge saat scrape agar /metrics tetap cepat walaupun DB lambat.
+const inventoryQueryTimeout = 2 * time.Second
+
+type activeLoanCounter interface {
+	CountActive(ctx context.Context) (int, error)
+}
+
+type outOfStockCounter interface {
+	CountOutOfStock(ctx context.Context) (int, error)
+}
+
+type outboxCounter interface {
+	CountUnpublished(ctx context.Context) (int, error)
+	CountDead(ctx context.Context) (int, error)
+}
+
+// RegisterDatabase mendaftarkan metric pool koneksi (sql.DBStats), gauge inventaris, dan antrean outbox.
+func RegisterDatabase(db *sql.DB, loans activeLoanCounter, books outOfStockCounter, outbox outboxCounter) {
+	Registry.MustRegister(
+		collectors.NewDBStatsCollector(db, "library_db"),
+		&inventoryCollector{loans: loans, books: books, outbox: outbox},
+	)
+}
+
+var (
+	activeLoansDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "active_loans"),
+		"Number of loans that have not been returned.",
+		nil, nil,
+	)
+	outOfStockDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "out_of_stock_titles"),
+		"Number of book titles with zero available stock.",
+		nil, nil,
+	)
+	outboxPendingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "outbox_pending_events"),
+		"Number of domain events in the outbox that have not been published yet (excluding dead events).",
+		nil, nil,
+	)
+	outboxDeadDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "outbox_dead_events"),
+		"Number of domain events that exceeded outbox.max_attempts and are no longer retried.",
+		nil, nil,
+	)
+)
+
+// inventoryCollector menghitung gauge langsung dari database saat di-scrape.
+// MENGAPA dihitung saat scrape, bukan di-update saat borrow/return?
+// - Nilainya selalu akurat, termasuk setelah perubahan dari libctl atau instance API lain
+// - Tidak ada state in-memory yang bisa melenceng dari database
+type inventoryCollector struct {
+	loans  activeLoanCounter
+	books  outOfStockCounter
+	outbox outboxCounter
+}
+
+func (c *inventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeLoansDesc
+	ch <- outOfStockDesc
+	ch <- outboxPendingDesc
+	ch <- outboxDeadDesc
+}
+
+func (c *inventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), inventoryQueryTimeout)
+	defer cancel()
+
+	// Query yang gagal hanya melewatkan metric-nya (bukan menggagalkan seluruh scrape),
+	// sehingga metric pool koneksi tetap terlihat saat database bermasalah.
+	if count, err := c.loans.CountActive(ctx); err != nil {
+		slog.Warn("failed to collect active loans metric", "error", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(activeLoansDesc, prometheus.GaugeValue, float64(count))
+	}
+
+	if count, err := c.books.CountOutOfStock(ctx); err != nil {
+		slog.Warn("failed to collect out of stock metric", "error", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(outOfStockDesc, prometheus.GaugeValue, float64(count))
+	}
+
+	if count, err := c.outbox.CountUnpublished(ctx); err != nil {
+		slog.Warn("failed to collect outbox pending metric", "error", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(outboxPendingDesc, prometheus.GaugeValue, float64(count))
+	}
+
+	if count, err := c.outbox.CountDead(ctx); err != nil {
+		slog.Warn("failed to collect outbox dead metric", "error", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(outboxDeadDesc, prometheus.GaugeValue, float64(count))
+	}
+}
@@ -0,0 +1,141 @@
+// Package metrics mendefinisikan metric Prometheus aplikasi dan endpoint /metrics.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	errorStruct "github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "library_api"
+
+// Registry terpisah dari prometheus.DefaultRegisterer.
+// Alasan: hanya metric yang didefinisikan di sini yang diekspos, dan tidak bentrok jika package lain
+// mendaftarkan metric ke registry global.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	apiErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "errors_total",
+		Help:      "Error responses by ZYD-ERR code and HTTP status.",
+	}, []string{"code", "status"})
+
+	loanOperationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "loan_operations_total",
+		Help:      "Loan operations by type (borrow, return, force_return), result (success, rejected, error) and ZYD-ERR code.",
+	}, []string{"operation", "result", "code"})
+
+	webhookDeliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts by event type and result (delivered, retry, dead).",
+	}, []string{"event", "result"})
+
+	outboxPublishTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_publish_total",
+		Help:      "Outbox event publish attempts by sink and result (published, failed).",
+	}, []string{"sink", "result"})
+
+	stockStreamSubscribers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stock_stream_subscribers",
+		Help:      "Number of connected stock availability SSE clients on this instance.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		apiErrorsTotal,
+		loanOperationsTotal,
+		webhookDeliveriesTotal,
+		outboxPublishTotal,
+		stockStreamSubscribers,
+	)
+}
+
+// Handler mengembalikan handler HTTP untuk endpoint /metrics.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest mencatat satu request HTTP.
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveAPIError mencatat error response yang dikirim ke client.
+func ObserveAPIError(code string, status int) {
+	apiErrorsTotal.WithLabelValues(code, strconv.Itoa(status)).Inc()
+}
+
+// ObserveLoanOperation mencatat hasil operasi peminjaman berdasarkan error yang dikembalikan service.
+// MENGAPA dibedakan rejected vs error?
+// - rejected = aturan bisnis menolak (stok habis, kuota penuh, dsb.), normal terjadi
+// - error = kegagalan sistem (ZYD-ERR-004 / error non-APIError), perlu ditindaklanjuti
+func ObserveLoanOperation(operation string, err error) {
+	if err == nil {
+		loanOperationsTotal.WithLabelValues(operation, "success", "").Inc()
+		return
+	}
+
+	var apiErr errorStruct.APIError
+	if !errors.As(err, &apiErr) {
+		loanOperationsTotal.WithLabelValues(operation, "error", "").Inc()
+		return
+	}
+
+	result := "rejected"
+	if apiErr.ZiyadErrCode == errorStruct.ErrCodeTxFailed {
+		result = "error"
+	}
+	loanOperationsTotal.WithLabelValues(operation, result, apiErr.ZiyadErrCode).Inc()
+}
+
+// ObserveWebhookDelivery mencatat satu percobaan pengiriman webhook.
+// Lonjakan result="dead" berarti endpoint integrator bermasalah dan dead-letter perlu diperiksa.
+func ObserveWebhookDelivery(eventType, result string) {
+	webhookDeliveriesTotal.WithLabelValues(eventType, result).Inc()
+}
+
+// ObserveOutboxPublish mencatat satu percobaan relay outbox mempublikasikan event ke sink.
+// result="failed" yang terus bertambah berarti sink bermasalah; event tertahan di outbox (lihat outbox_pending_events)
+// sampai menjadi dead (outbox_dead_events).
+func ObserveOutboxPublish(sink string, err error) {
+	result := "published"
+	if err != nil {
+		result = "failed"
+	}
+	outboxPublishTotal.WithLabelValues(sink, result).Inc()
+}
+
+// SetStockStreamSubscribers mencatat jumlah client stream stok yang sedang tersambung ke instance ini.
+func SetStockStreamSubscribers(count int) {
+	stockStreamSubscribers.Set(float64(count))
+}
@@ -0,0 +1,301 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+)
+
+const (
+	// lockName dipakai oleh GET_LOCK agar hanya satu instance yang menjalankan migration.
+	lockName = "library_api_schema_migrations"
+
+	defaultLockTimeout = 30 * time.Second
+)
+
+// ErrLockTimeout dikembalikan jika instance lain masih memegang migration lock.
+var ErrLockTimeout = errors.New("migration lock is held by another instance")
+
+// Status menggambarkan kondisi satu migration terhadap database.
+type Status struct {
+	Migration Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db          *sql.DB
+	migrations  []Migration
+	lockTimeout time.Duration
+}
+
+func New(db *sql.DB, schema fs.FS) (*Migrator, error) {
+	migrations, err := Load(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:          db,
+		migrations:  migrations,
+		lockTimeout: defaultLockTimeout,
+	}, nil
+}
+
+// Up menjalankan semua migration yang belum tercatat di schema_migrations secara berurutan.
+// Mengembalikan daftar migration yang baru saja diterapkan.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			if err := execScript(ctx, conn, migration.Up); err != nil {
+				return fmt.Errorf("migration %d_%s up failed: %w", migration.Version, migration.Name, err)
+			}
+
+			// Alasan mencatat versi setelah script berhasil (bukan dalam satu transaksi):
+			// - DDL di MySQL melakukan implicit commit, sehingga transaksi tidak bisa membungkus CREATE/ALTER.
+			// - Karena itu script migration ditulis idempotent agar aman diulang jika proses mati di tengah:
+			//   CREATE/DROP TABLE memakai IF [NOT] EXISTS, dan ADD/DROP COLUMN (yang tidak punya IF NOT EXISTS di MySQL)
+			//   dijaga dengan pengecekan information_schema.COLUMNS (contoh: 000004_add_books_total_copies.up.sql).
+			if _, err := conn.ExecContext(ctx,
+				`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`,
+				migration.Version, migration.Name,
+			); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down me-rollback sejumlah steps migration terakhir yang sudah diterapkan.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("steps harus lebih dari 0")
+	}
+
+	var reverted []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+			}
+
+			if err := execScript(ctx, conn, migration.Down); err != nil {
+				return fmt.Errorf("migration %d_%s down failed: %w", migration.Version, migration.Name, err)
+			}
+
+			if _, err := conn.ExecContext(ctx,
+				`DELETE FROM schema_migrations WHERE version = ?`, migration.Version,
+			); err != nil {
+				return fmt.Errorf("failed to unrecord migration %d: %w", migration.Version, err)
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status mengembalikan kondisi setiap migration yang dikenal binary ini.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	versions, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = Status{Migration: migration}
+		if appliedAt, ok := versions[migration.Version]; ok {
+			statuses[i].Applied = true
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+
+	return statuses, nil
+}
+
+// Version mengembalikan versi tertinggi yang sudah diterapkan, versi terbaru yang dikenal binary,
+// dan jumlah migration yang belum diterapkan.
+// Berbeda dengan Status, Version tidak membuat tabel schema_migrations (read-only untuk health check).
+func (m *Migrator) Version(ctx context.Context) (current, latest int64, pending int, err error) {
+	versions := make(map[int64]struct{})
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return 0, 0, 0, err
+		}
+		versions[version] = struct{}{}
+		if version > current {
+			current = version
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, 0, err
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version > latest {
+			latest = migration.Version
+		}
+		if _, ok := versions[migration.Version]; !ok {
+			pending++
+		}
+	}
+
+	return current, latest, pending, nil
+}
+
+// Seed menjalankan semua file fixture (*.sql) di root fsys, diurutkan berdasarkan nama file.
+// Fixture tidak dicatat di schema_migrations, sehingga script-nya wajib idempotent.
+func (m *Migrator) Seed(ctx context.Context, fixtures fs.FS) ([]string, error) {
+	names, err := fs.Glob(fixtures, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		for _, name := range names {
+			content, err := fs.ReadFile(fixtures, name)
+			if err != nil {
+				return fmt.Errorf("failed to read fixture %s: %w", name, err)
+			}
+
+			if err := execScript(ctx, conn, string(content)); err != nil {
+				return fmt.Errorf("fixture %s failed: %w", name, err)
+			}
+		}
+		return nil
+	})
+
+	return names, err
+}
+
+// withLock menjalankan fn di satu koneksi khusus yang memegang MySQL named lock.
+// MENGAPA GET_LOCK dan bukan lock di tabel?
+// - Named lock tidak membutuhkan tabel (schema_migrations mungkin belum ada)
+// - Lock otomatis dilepas MySQL jika koneksi putus (instance crash), sehingga tidak ada lock yatim
+// - GET_LOCK terikat ke koneksi, karena itu semua statement harus dijalankan di *sql.Conn yang sama
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx,
+		`SELECT GET_LOCK(?, ?)`, lockName, int(m.lockTimeout.Seconds()),
+	).Scan(&acquired); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return ErrLockTimeout
+	}
+
+	// Release memakai context.Background agar lock tetap dilepas walaupun ctx sudah dibatalkan.
+	defer conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, lockName)
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `
+       CREATE TABLE IF NOT EXISTS schema_migrations
+       (
+           version    BIGINT PRIMARY KEY,
+           name       VARCHAR(255) NOT NULL,
+           applied_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+       ) ENGINE = InnoDB
+         DEFAULT CHARSET = utf8mb4
+    `)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		versions[version] = appliedAt
+	}
+
+	return versions, rows.Err()
+}
+
+func execScript(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,16 +5,28 @@ import (
 )
 
 type Book struct {
-	ID     int    `json:"id"`
-	Title  string `json:"title"`
-	Author string `json:"author"`
-	Stock  int    `json:"stock"`
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Author      string `json:"author"`
+	TotalCopies int    `json:"total_copies"`
+	Stock       int    `json:"stock"`
+}
+
+// StockLedger membandingkan stok tersimpan dengan stok yang seharusnya (total_copies - pinjaman aktif).
+type StockLedger struct {
+	BookID      int
+	Title       string
+	TotalCopies int
+	Stock       int
+	ActiveLoans int
 }
 
 type Member struct {
 	ID    int    `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	// Language adalah preferensi bahasa pesan API (id/en), kosong jika member belum memilih.
+	Language string `json:"language,omitempty"`
 }
 
 type Loan struct {
@@ -25,6 +37,94 @@ type Loan struct {
 	ReturnedAt *time.Time `json:"returned_at,omitempty"`
 
 	// Additional fields untuk response
-	BookTitle  string `json:"book_title,omitempty"`
-	BookAuthor string `json:"book_author,omitempty"`
+	BookTitle   string `json:"book_title,omitempty"`
+	BookAuthor  string `json:"book_author,omitempty"`
+	MemberName  string `json:"member_name,omitempty"`
+	MemberEmail string `json:"member_email,omitempty"`
+}
+
+// Status reservasi (holds.status).
+const (
+	HoldWaiting   = "waiting"
+	HoldFulfilled = "fulfilled"
+	HoldCancelled = "cancelled"
+)
+
+// Hold adalah reservasi member untuk buku yang stoknya habis.
+type Hold struct {
+	ID        int
+	MemberID  int
+	BookID    int
+	Status    string
+	CreatedAt time.Time
+	ClosedAt  *time.Time
+
+	// Ahead adalah jumlah reservasi waiting untuk buku yang sama di depan reservasi ini;
+	// hanya diisi oleh query yang menghitung posisi antrean.
+	Ahead int
+}
+
+// Fine adalah denda keterlambatan satu pinjaman.
+type Fine struct {
+	ID          int
+	LoanID      int
+	MemberID    int
+	BookID      int
+	DaysOverdue int
+	Amount      int
+	CreatedAt   time.Time
+	PaidAt      *time.Time
+}
+
+// WebhookSubscription adalah endpoint integrator yang menerima event tertentu.
+type WebhookSubscription struct {
+	ID        int
+	URL       string
+	Secret    string
+	Events    []string
+	Active    bool
+	CreatedAt time.Time
+}
+
+// WebhookDelivery adalah satu event yang dikirim ke satu subscription, beserta status percobaannya.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int
+	EventID        string
+	EventType      string
+	Aggregate      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+
+	// URL & Secret subscription, hanya diisi saat delivery diklaim untuk dikirim.
+	URL    string
+	Secret string
+}
+
+// OutboxEvent adalah event domain yang ditulis bersama transaksinya dan menunggu dipublikasikan relay.
+type OutboxEvent struct {
+	ID            int64
+	EventID       string
+	EventType     string
+	Aggregate     string
+	Payload       []byte
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+// StockChange adalah satu perubahan stok buku yang sudah commit, dipakai stream SSE ketersediaan buku.
+type StockChange struct {
+	ID     int64
+	BookID int
+	Stock  int
+	Delta  int
 }
@@ -0,0 +1,69 @@
+package openapi
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// CheckRoutes membandingkan route yang terdaftar di router dengan path & method di dokumen.
+// Dipakai oleh test drift (route baru tanpa dokumentasi, atau dokumentasi untuk route yang sudah dihapus,
+// menggagalkan CI) dan sebagai peringatan log saat startup.
+func CheckRoutes(router *mux.Router, doc *Document) error {
+	registered := make(map[string]bool)
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		// Route tanpa method adalah subrouter/prefix (misalnya /api/v1), bukan endpoint.
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			// OPTIONS hanya untuk preflight CORS, bukan bagian dari kontrak API.
+			if method == http.MethodOptions {
+				continue
+			}
+			registered[method+" "+path] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	var errs []error
+	for _, route := range missing(registered, documented) {
+		errs = append(errs, fmt.Errorf("route %s belum didokumentasikan di OpenAPI (internal/openapi/operations.go)", route))
+	}
+	for _, route := range missing(documented, registered) {
+		errs = append(errs, fmt.Errorf("OpenAPI mendokumentasikan %s tetapi route tersebut tidak terdaftar", route))
+	}
+
+	return stdErrors.Join(errs...)
+}
+
+// missing mengembalikan key di a yang tidak ada di b, terurut agar pesan error stabil.
+func missing(a, b map[string]bool) []string {
+	var result []string
+	for key := range a {
+		if !b[key] {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,23 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Ar1veeee/library-api/internal/http/routes"
+	"github.com/Ar1veeee/library-api/internal/openapi"
+	"github.com/gorilla/mux"
+)
+
+// TestRoutesMatchDocument gagal jika route di routes.RegisterRoutes dan dokumen OpenAPI tidak sama,
+// sehingga drift ketahuan di CI, bukan saat deploy.
+func TestRoutesMatchDocument(t *testing.T) {
+	router := mux.NewRouter()
+	// Handler tidak pernah dipanggil: CheckRoutes hanya membaca path & method yang terdaftar.
+	passthrough := func(next http.Handler) http.Handler { return next }
+	routes.RegisterRoutes(router, nil, nil, nil, nil, nil, nil, nil, nil, nil, passthrough)
+
+	if err := openapi.CheckRoutes(router, openapi.Build()); err != nil {
+		t.Fatalf("OpenAPI document drifted from registered routes:\n%v", err)
+	}
+}
@@ -0,0 +1,395 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/buildinfo"
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/errors"
+)
+
+// operation adalah satu baris tabel route. Setiap route di routes.RegisterRoutes wajib punya baris di sini;
+// CheckRoutes menggagalkan startup jika keduanya tidak sama.
+type operation struct {
+	method      string
+	path        string
+	id          string
+	tag         string
+	summary     string
+	description string
+	params      []Parameter
+
+	// request adalah DTO body request (nil jika tanpa body).
+	request any
+	// data adalah isi field "data" pada dto.SuccessResponse; abaikan jika raw diisi.
+	data any
+	// noData berarti response sukses berupa dto.SuccessResponse tanpa field data (misalnya return buku).
+	noData bool
+	// raw adalah response sukses yang tidak memakai envelope SuccessResponse (probe, metrics, docs).
+	raw         any
+	contentType string
+	status      int
+
+	// extraStatuses adalah status non-error lain dengan body yang sama (misalnya 503 saat health gagal).
+	extraStatuses map[int]string
+	// errorCodes adalah kode ZYD-ERR spesifik endpoint; kode umum API ditambahkan oleh apiRoute.
+	errorCodes []string
+}
+
+const (
+	tagLoans    = "Loans"
+	tagBooks    = "Books"
+	tagMembers  = "Members"
+	tagSystem   = "System"
+	tagDocs     = "Documentation"
+	tagGraphQL  = "GraphQL"
+	tagWebhooks = "Webhooks"
+)
+
+// webhookErrorCodes berlaku untuk semua route /api/v1/webhooks yang dilindungi API key.
+var webhookErrorCodes = []string{errors.ErrCodeUnauthenticated}
+
+// commonAPIErrorCodes berlaku untuk semua route /api/v1: kegagalan internal dan rate limit.
+var commonAPIErrorCodes = []string{errors.ErrCodeTxFailed, errors.ErrCodeRateLimited}
+
+// stockStreamDescription menjelaskan format event SSE, karena OpenAPI tidak punya schema untuk isi stream.
+const stockStreamDescription = "Server-Sent Events: setiap perubahan stok dikirim sebagai `event: stock` dengan `id` " +
+	"berurutan dan `data` JSON {\"book_id\", \"stock\", \"delta\"} (stock = stok setelah perubahan). " +
+	"Komentar `: heartbeat` dikirim berkala saat tidak ada perubahan. Sambung ulang dengan Last-Event-ID untuk " +
+	"menerima perubahan yang terlewat."
+
+func stockStreamParams() []Parameter {
+	minimum := 0.0
+	return []Parameter{
+		{
+			Name: "Last-Event-ID", In: "header",
+			Description: "ID event terakhir yang diterima; dikirim otomatis oleh EventSource saat menyambung ulang",
+			Schema:      &Schema{Type: "integer", Minimum: &minimum},
+		},
+		{
+			Name: "last_event_id", In: "query",
+			Description: "Sama dengan Last-Event-ID, untuk koneksi pertama (EventSource tidak bisa mengirim header)",
+			Schema:      &Schema{Type: "integer", Minimum: &minimum},
+		},
+	}
+}
+
+func idParam(description string) Parameter {
+	minimum := 0.0
+	return Parameter{
+		Name:        "id",
+		In:          "path",
+		Required:    true,
+		Description: description,
+		Schema:      &Schema{Type: "integer", Minimum: &minimum, ExclusiveMinimum: true},
+	}
+}
+
+func operations() []operation {
+	return []operation{
+		{
+			method: http.MethodGet, path: "/livez", id: "live", tag: tagSystem,
+			summary:     "Liveness probe",
+			description: "Hanya gagal jika proses macet; tidak mengecek database.",
+			raw:         dto.LivenessResponse{}, status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, path: "/readyz", id: "ready", tag: tagSystem,
+			summary:     "Readiness probe",
+			description: "Mengecek status drain, koneksi database dan migration yang tertunda.",
+			raw:         dto.ReadinessResponse{}, status: http.StatusOK,
+			extraStatuses: map[int]string{http.StatusServiceUnavailable: "Instance belum/tidak siap menerima traffic"},
+		},
+		{
+			method: http.MethodGet, path: "/metrics", id: "metrics", tag: tagSystem,
+			summary: "Prometheus metrics",
+			raw:     "", contentType: "text/plain", status: http.StatusOK,
+		},
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/health", id: "health", tag: tagSystem,
+			summary: "Health check (legacy)",
+			raw:     dto.LivenessResponse{}, status: http.StatusOK,
+			extraStatuses: map[int]string{http.StatusServiceUnavailable: "Server sedang shutdown (draining)"},
+		}),
+		apiRoute(operation{
+			method: http.MethodPost, path: "/api/v1/borrow", id: "borrowBook", tag: tagLoans,
+			summary:     "Pinjam buku",
+			description: "Mengurangi stok dan mencatat peminjaman dalam satu transaksi.",
+			request:     dto.BorrowBookRequest{}, data: dto.LoanDetail{}, status: http.StatusCreated,
+			errorCodes: []string{
+				errors.ErrCodeInvalidInput, errors.ErrCodeNotFound, errors.ErrCodeQuotaExceeded,
+				errors.ErrCodeStockEmpty, errors.ErrCodeAlreadyBorrowed,
+			},
+		}),
+		apiRoute(operation{
+			method: http.MethodPost, path: "/api/v1/return", id: "returnBook", tag: tagLoans,
+			summary: "Kembalikan buku",
+			request: dto.ReturnBookRequest{}, noData: true, status: http.StatusOK,
+			errorCodes: []string{errors.ErrCodeInvalidInput, errors.ErrCodeNotFound},
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/books", id: "listBooks", tag: tagBooks,
+			summary: "Daftar buku",
+			data:    dto.BooksListResponse{}, status: http.StatusOK,
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/books/{id}", id: "getBook", tag: tagBooks,
+			summary: "Detail buku",
+			params:  []Parameter{idParam("ID buku")},
+			data:    dto.BookResponse{}, status: http.StatusOK,
+			errorCodes: []string{errors.ErrCodeInvalidInput, errors.ErrCodeNotFound},
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/books/stream", id: "streamCatalogStock", tag: tagBooks,
+			summary:     "Stream stok semua buku (SSE)",
+			description: stockStreamDescription,
+			params:      stockStreamParams(),
+			raw:         "", contentType: "text/event-stream", status: http.StatusOK,
+			errorCodes: []string{errors.ErrCodeInvalidInput},
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/books/{id}/stream", id: "streamBookStock", tag: tagBooks,
+			summary:     "Stream stok satu buku (SSE)",
+			description: stockStreamDescription,
+			params:      append([]Parameter{idParam("ID buku")}, stockStreamParams()...),
+			raw:         "", contentType: "text/event-stream", status: http.StatusOK,
+			errorCodes: []string{errors.ErrCodeInvalidInput, errors.ErrCodeNotFound},
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/members/{id}/loans", id: "getMemberLoans", tag: tagMembers,
+			summary: "Riwayat peminjaman member",
+			params:  []Parameter{idParam("ID member")},
+			data:    dto.MemberLoansResponse{}, status: http.StatusOK,
+			errorCodes: []string{errors.ErrCodeInvalidInput, errors.ErrCodeNotFound},
+		}),
+		apiRoute(operation{
+			method: http.MethodPost, path: "/graphql", id: "graphql", tag: tagGraphQL,
+			summary:     "Query GraphQL (read-only)",
+			description: "Schema: books, book(id), member(id) dengan loans → book/member. Error query dikirim di field \"errors\" dengan extensions.code = kode ZYD-ERR.",
+			request:     dto.GraphQLRequest{}, raw: map[string]any{}, status: http.StatusOK,
+			errorCodes: []string{errors.ErrCodeInvalidInput},
+		}),
+		apiRoute(operation{
+			method: http.MethodPost, path: "/api/v1/webhooks", id: "createWebhook", tag: tagWebhooks,
+			summary:     "Daftarkan webhook",
+			description: "Secret untuk memverifikasi header X-Library-Signature hanya dikirim di response ini. URL ke alamat loopback/link-local/private ditolak.",
+			request:     dto.CreateWebhookRequest{}, data: dto.WebhookResponse{}, status: http.StatusCreated,
+			errorCodes: append([]string{errors.ErrCodeInvalidInput}, webhookErrorCodes...),
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/webhooks", id: "listWebhooks", tag: tagWebhooks,
+			summary: "Daftar webhook",
+			data:    []dto.WebhookResponse{}, status: http.StatusOK,
+			errorCodes: webhookErrorCodes,
+		}),
+		apiRoute(operation{
+			method: http.MethodDelete, path: "/api/v1/webhooks/{id}", id: "deleteWebhook", tag: tagWebhooks,
+			summary:     "Nonaktifkan webhook",
+			description: "Pengiriman yang masih tertunda dipindahkan ke dead-letter; delivery log tetap bisa dibaca.",
+			params:      []Parameter{idParam("ID webhook")},
+			noData:      true, status: http.StatusOK,
+			errorCodes: append([]string{errors.ErrCodeInvalidInput, errors.ErrCodeNotFound}, webhookErrorCodes...),
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/webhooks/{id}/deliveries", id: "listWebhookDeliveries", tag: tagWebhooks,
+			summary: "Delivery log webhook (100 terbaru)",
+			params:  []Parameter{idParam("ID webhook")},
+			data:    []dto.WebhookDeliveryResponse{}, status: http.StatusOK,
+			errorCodes: append([]string{errors.ErrCodeInvalidInput, errors.ErrCodeNotFound}, webhookErrorCodes...),
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/webhooks/dead-letters", id: "listWebhookDeadLetters", tag: tagWebhooks,
+			summary: "Dead-letter webhook (100 terbaru)",
+			data:    []dto.WebhookDeliveryResponse{}, status: http.StatusOK,
+			errorCodes: webhookErrorCodes,
+		}),
+		apiRoute(operation{
+			method: http.MethodPost, path: "/api/v1/webhooks/deliveries/{id}/retry", id: "retryWebhookDelivery", tag: tagWebhooks,
+			summary: "Kirim ulang dead-letter",
+			params:  []Parameter{idParam("ID pengiriman")},
+			data:    dto.WebhookDeliveryResponse{}, status: http.StatusOK,
+			errorCodes: append([]string{errors.ErrCodeInvalidInput, errors.ErrCodeNotFound}, webhookErrorCodes...),
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/errors", id: "listErrorCodes", tag: tagDocs,
+			summary: "Katalog kode error",
+			data:    []dto.ErrorCodeResponse{}, status: http.StatusOK,
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/errors/{code}", id: "getErrorCode", tag: tagDocs,
+			summary: "Detail kode error",
+			params: []Parameter{{
+				Name: "code", In: "path", Required: true, Description: "Kode error, misalnya ZYD-ERR-001",
+				Schema: &Schema{Type: "string"},
+			}},
+			data: dto.ErrorCodeResponse{}, status: http.StatusOK,
+			errorCodes: []string{errors.ErrCodeNotFound},
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/openapi.json", id: "getOpenAPI", tag: tagDocs,
+			summary: "Dokumen OpenAPI ini",
+			raw:     map[string]any{}, status: http.StatusOK,
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/docs", id: "getDocs", tag: tagDocs,
+			summary: "Docs UI (HTML)",
+			raw:     "", contentType: "text/html", status: http.StatusOK,
+		}),
+		apiRoute(operation{
+			method: http.MethodGet, path: "/api/v1/docs/{asset}", id: "getDocsAsset", tag: tagDocs,
+			summary: "Asset statis docs UI",
+			params: []Parameter{{
+				Name: "asset", In: "path", Required: true,
+				Schema: &Schema{Type: "string", Enum: Assets()},
+			}},
+			raw: "", contentType: "text/plain", status: http.StatusOK,
+			errorCodes: []string{errors.ErrCodeNotFound},
+		}),
+	}
+}
+
+// apiRoute menambahkan kode error yang berlaku untuk semua route /api/v1.
+func apiRoute(op operation) operation {
+	op.errorCodes = append(append([]string(nil), op.errorCodes...), commonAPIErrorCodes...)
+	return op
+}
+
+// Build menghasilkan dokumen OpenAPI untuk semua route.
+func Build() *Document {
+	gen := newSchemaGenerator()
+
+	errorResponse := gen.schemaOf(reflect.TypeOf(dto.ErrorResponse{}))
+	problemDetails := gen.schemaOf(reflect.TypeOf(dto.ProblemDetails{}))
+	gen.schemas["ErrorCode"] = errorCodeSchema()
+	for _, name := range []string{"ErrorResponse", "ProblemDetails"} {
+		gen.schemas[name].Properties["ziyad_error_code"] = ref("ErrorCode")
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "Library API",
+			Description: "API peminjaman buku perpustakaan. Pesan mengikuti Accept-Language (id/en); error tersedia sebagai application/problem+json jika diminta lewat Accept.",
+			Version:     buildinfo.Get().Version,
+		},
+		Servers: []Server{{URL: "/"}},
+		Tags: []Tag{
+			{Name: tagLoans}, {Name: tagBooks}, {Name: tagMembers}, {Name: tagGraphQL}, {Name: tagWebhooks}, {Name: tagSystem}, {Name: tagDocs},
+		},
+		Paths: make(map[string]PathItem),
+	}
+
+	for _, op := range operations() {
+		item, ok := doc.Paths[op.path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[op.path] = item
+		}
+		item[strings.ToLower(op.method)] = buildOperation(gen, op, errorResponse, problemDetails)
+	}
+
+	doc.Components = Components{Schemas: gen.schemas}
+	return doc
+}
+
+func buildOperation(gen *schemaGenerator, op operation, errorResponse, problemDetails *Schema) *Operation {
+	operation := &Operation{
+		Tags:        []string{op.tag},
+		Summary:     op.summary,
+		Description: op.description,
+		OperationID: op.id,
+		Parameters:  op.params,
+		Responses:   make(map[string]Response),
+	}
+
+	if op.request != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: gen.schemaOf(reflect.TypeOf(op.request))}},
+		}
+	}
+
+	success := successContent(gen, op)
+	operation.Responses[strconv.Itoa(op.status)] = Response{Description: http.StatusText(op.status), Content: success}
+	for status, description := range op.extraStatuses {
+		operation.Responses[strconv.Itoa(status)] = Response{Description: description, Content: success}
+	}
+
+	// Kode error dikelompokkan per HTTP status dari registry, karena satu status bisa punya beberapa kode (409).
+	byStatus := make(map[int][]string)
+	for _, code := range op.errorCodes {
+		definition, ok := errors.Lookup(code)
+		if !ok {
+			panic(fmt.Sprintf("openapi: operation %s memakai kode error %s yang tidak terdaftar", op.id, code))
+		}
+		byStatus[definition.HTTPStatus] = append(byStatus[definition.HTTPStatus], code)
+	}
+
+	errorContent := map[string]MediaType{
+		"application/json":         {Schema: errorResponse},
+		"application/problem+json": {Schema: problemDetails},
+	}
+	for status, codes := range byStatus {
+		sort.Strings(codes)
+		response := Response{
+			Description: http.StatusText(status) + ": " + strings.Join(codes, ", "),
+			Content:     errorContent,
+		}
+		if status == http.StatusTooManyRequests {
+			response.Headers = map[string]Header{
+				"Retry-After": {Description: "Detik sampai request boleh dicoba lagi", Schema: &Schema{Type: "integer"}},
+			}
+		}
+		operation.Responses[strconv.Itoa(status)] = response
+	}
+
+	return operation
+}
+
+func successContent(gen *schemaGenerator, op operation) map[string]MediaType {
+	contentType := op.contentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	if op.raw != nil {
+		return map[string]MediaType{contentType: {Schema: gen.schemaOf(reflect.TypeOf(op.raw))}}
+	}
+
+	// Envelope dto.SuccessResponse dengan tipe "data" yang spesifik per endpoint.
+	envelope := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"message": {Type: "string"}},
+		Required:   []string{"message"},
+	}
+	if !op.noData {
+		envelope.Properties["data"] = gen.schemaOf(reflect.TypeOf(op.data))
+		envelope.Required = append(envelope.Required, "data")
+	}
+
+	return map[string]MediaType{contentType: {Schema: envelope}}
+}
+
+// errorCodeSchema mendaftar semua kode dari registry beserta HTTP status dan penjelasannya.
+func errorCodeSchema() *Schema {
+	definitions := errors.Definitions()
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Code < definitions[j].Code })
+
+	codes := make([]string, len(definitions))
+	lines := make([]string, len(definitions))
+	for i, definition := range definitions {
+		codes[i] = definition.Code
+		lines[i] = fmt.Sprintf("- `%s` (%d): %s", definition.Code, definition.HTTPStatus, definition.Description)
+	}
+
+	return &Schema{
+		Type:        "string",
+		Enum:        codes,
+		Description: "Kode error aplikasi (lihat juga GET /api/v1/errors):\n" + strings.Join(lines, "\n"),
+	}
+}
@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// schemaGenerator menurunkan schema dari tipe Go. Struct bernama didaftarkan sekali ke components
+// dan dirujuk lewat $ref, sehingga DTO yang dipakai di banyak endpoint hanya didefinisikan satu kali.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{schemas: make(map[string]*Schema)}
+}
+
+func (g *schemaGenerator) schemaOf(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		// Pointer di DTO berarti nilai bisa null (misalnya returned_at sebelum buku dikembalikan).
+		// OpenAPI 3.0 mengabaikan properti lain di samping $ref, sehingga $ref dibungkus allOf.
+		inner := g.schemaOf(t.Elem())
+		if inner.Ref != "" {
+			return &Schema{AllOf: []*Schema{inner}, Nullable: true}
+		}
+		nullable := *inner
+		nullable.Nullable = true
+		return &nullable
+	case reflect.Struct:
+		return g.structRef(t)
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *schemaGenerator) structRef(t reflect.Type) *Schema {
+	name := t.Name()
+	if _, ok := g.schemas[name]; ok {
+		return ref(name)
+	}
+
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	// Didaftarkan sebelum field diproses agar struct yang merujuk dirinya sendiri tidak berulang tanpa henti.
+	g.schemas[name] = schema
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaOf(field.Type)
+		required := applyValidateTag(property, field.Tag.Get("validate"), strings.Contains(options, "omitempty"))
+
+		// Semua timestamp di response diformat RFC 3339 oleh service (formatTimestamp) dan memakai suffix _at.
+		if property.Type == "string" && strings.HasSuffix(name, "_at") {
+			property.Format = "date-time"
+		}
+
+		schema.Properties[name] = property
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return ref(name)
+}
+
+// applyValidateTag menerjemahkan tag validate (go-playground/validator) menjadi batasan schema
+// dan menentukan apakah field wajib ada.
+// Field tanpa tag validate (DTO response) dianggap selalu ada kecuali bertanda omitempty.
+func applyValidateTag(schema *Schema, tag string, omitempty bool) bool {
+	if tag == "" {
+		return !omitempty
+	}
+
+	required := false
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			// Aturan setelah dive berlaku untuk setiap elemen slice, bukan untuk slice-nya.
+			if schema.Items != nil {
+				applyValidateTag(schema.Items, strings.Join(rules[i+1:], ","), false)
+			}
+			return required
+		case "gt":
+			schema.Minimum = parseFloat(param)
+			schema.ExclusiveMinimum = true
+		case "gte", "min":
+			switch schema.Type {
+			case "string":
+				schema.MinLength = parseInt(param)
+			case "array":
+				schema.MinItems = parseInt(param)
+			default:
+				schema.Minimum = parseFloat(param)
+			}
+		case "lte", "max":
+			if schema.Type == "string" {
+				schema.MaxLength = parseInt(param)
+			} else {
+				schema.Maximum = parseFloat(param)
+			}
+		case "email":
+			schema.Format = "email"
+		case "http_url":
+			schema.Format = "uri"
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		}
+	}
+
+	return required
+}
+
+func parseInt(s string) *int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func parseFloat(s string) *float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
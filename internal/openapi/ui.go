@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"embed"
+	"io/fs"
+	"mime"
+	"path"
+)
+
+// ui berisi docs UI statis tanpa dependency CDN, sehingga bisa dipakai di jaringan tertutup
+// dan lolos Content-Security-Policy yang hanya mengizinkan resource dari origin sendiri.
+//
+//go:embed ui
+var ui embed.FS
+
+// IndexHTML adalah halaman docs UI.
+func IndexHTML() []byte {
+	page, _ := ui.ReadFile("ui/index.html")
+	return page
+}
+
+// Asset mengembalikan isi & content type asset docs UI (misalnya docs.js); ok=false jika tidak ada.
+func Asset(name string) (content []byte, contentType string, ok bool) {
+	if name == "index.html" || name != path.Base(name) {
+		return nil, "", false
+	}
+	content, err := ui.ReadFile("ui/" + name)
+	if err != nil {
+		return nil, "", false
+	}
+	return content, mime.TypeByExtension(path.Ext(name)), true
+}
+
+// Assets mendaftar nama asset yang bisa diambil lewat /api/v1/docs/{asset}.
+func Assets() []string {
+	entries, _ := fs.ReadDir(ui, "ui")
+	var names []string
+	for _, entry := range entries {
+		if entry.Name() != "index.html" {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
@@ -0,0 +1,128 @@
+// Docs UI minimal untuk openapi.json: tanpa framework/CDN agar bisa di-embed dan lolos CSP 'self'.
+(function () {
+  "use strict";
+
+  function el(tag, attrs, children) {
+    var node = document.createElement(tag);
+    Object.keys(attrs || {}).forEach(function (key) {
+      node.setAttribute(key, attrs[key]);
+    });
+    (children || []).forEach(function (child) {
+      node.appendChild(typeof child === "string" ? document.createTextNode(child) : child);
+    });
+    return node;
+  }
+
+  // resolve mengganti $ref dengan schema aslinya (dengan batas kedalaman untuk schema rekursif).
+  function resolve(spec, schema, depth) {
+    if (!schema || depth > 6) {
+      return schema;
+    }
+    if (schema.$ref) {
+      var name = schema.$ref.split("/").pop();
+      return resolve(spec, spec.components.schemas[name], depth + 1);
+    }
+    var copy = Object.assign({}, schema);
+    if (copy.properties) {
+      copy.properties = {};
+      Object.keys(schema.properties).forEach(function (key) {
+        copy.properties[key] = resolve(spec, schema.properties[key], depth + 1);
+      });
+    }
+    if (copy.items) {
+      copy.items = resolve(spec, copy.items, depth + 1);
+    }
+    if (copy.allOf) {
+      copy.allOf = copy.allOf.map(function (s) { return resolve(spec, s, depth + 1); });
+    }
+    if (copy.additionalProperties) {
+      copy.additionalProperties = resolve(spec, copy.additionalProperties, depth + 1);
+    }
+    return copy;
+  }
+
+  function schemaBlock(spec, schema) {
+    return el("pre", {}, [JSON.stringify(resolve(spec, schema, 0), null, 2)]);
+  }
+
+  function contentBlocks(spec, content) {
+    return Object.keys(content || {}).map(function (type) {
+      return el("div", {}, [el("p", {}, [el("code", {}, [type])]), schemaBlock(spec, content[type].schema)]);
+    });
+  }
+
+  function renderOperation(spec, path, method, op) {
+    var body = [];
+    if (op.description) {
+      body.push(el("p", {}, [op.description]));
+    }
+
+    if (op.parameters && op.parameters.length) {
+      var rows = op.parameters.map(function (p) {
+        return el("tr", {}, [
+          el("td", {}, [el("code", {}, [p.name])]),
+          el("td", {}, [p.in]),
+          el("td", {}, [p.required ? "ya" : "tidak"]),
+          el("td", {}, [p.description || ""])
+        ]);
+      });
+      body.push(el("h4", {}, ["Parameter"]));
+      body.push(el("table", {}, [el("tr", {}, [
+        el("th", {}, ["Nama"]), el("th", {}, ["Lokasi"]), el("th", {}, ["Wajib"]), el("th", {}, ["Keterangan"])
+      ])].concat(rows)));
+    }
+
+    if (op.requestBody) {
+      body.push(el("h4", {}, ["Request body"]));
+      body = body.concat(contentBlocks(spec, op.requestBody.content));
+    }
+
+    body.push(el("h4", {}, ["Responses"]));
+    Object.keys(op.responses).sort().forEach(function (status) {
+      var response = op.responses[status];
+      var details = el("details", {}, [
+        el("summary", {}, [el("span", { "class": "status" }, [status]), " " + response.description])
+      ].concat(contentBlocks(spec, response.content)));
+      body.push(details);
+    });
+
+    return el("details", { id: op.operationId }, [
+      el("summary", {}, [
+        el("span", { "class": "method " + method }, [method.toUpperCase()]),
+        el("span", { "class": "path" }, [path]),
+        " — " + (op.summary || "")
+      ])
+    ].concat(body));
+  }
+
+  function render(spec) {
+    document.getElementById("title").textContent = spec.info.title + " " + spec.info.version;
+    document.getElementById("description").textContent = spec.info.description || "";
+
+    var byTag = {};
+    Object.keys(spec.paths).sort().forEach(function (path) {
+      Object.keys(spec.paths[path]).forEach(function (method) {
+        var op = spec.paths[path][method];
+        var tag = (op.tags && op.tags[0]) || "Lainnya";
+        (byTag[tag] = byTag[tag] || []).push(renderOperation(spec, path, method, op));
+      });
+    });
+
+    var main = document.getElementById("operations");
+    main.textContent = "";
+    (spec.tags || []).map(function (t) { return t.name; }).forEach(function (tag) {
+      if (!byTag[tag]) {
+        return;
+      }
+      main.appendChild(el("h2", {}, [tag]));
+      byTag[tag].forEach(function (node) { main.appendChild(node); });
+    });
+  }
+
+  fetch("openapi.json")
+    .then(function (response) { return response.json(); })
+    .then(render)
+    .catch(function (err) {
+      document.getElementById("operations").textContent = "Gagal memuat openapi.json: " + err;
+    });
+})();
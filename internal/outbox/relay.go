@@ -0,0 +1,245 @@
+// Package outbox mempublikasikan domain event yang ditulis LoanService ke tabel outbox_events
+// (transactional outbox) ke satu atau beberapa Sink.
+//
+// Jaminan:
+//   - At-least-once: event ditandai published setelah semua sink berhasil; jika proses mati di antaranya,
+//     event dipublikasikan ulang. Penerima mengabaikan duplikat berdasarkan ID event.
+//   - Urutan per aggregate: event yang gagal menahan event berikutnya dari aggregate yang sama sampai berhasil,
+//     sehingga misalnya loan.returned tidak pernah mendahului loan.created untuk pinjaman yang sama.
+//     Sink webhook meneruskan jaminan ini per subscription (lihat package webhook).
+//     Aggregate yang tertahan dilewati saat membaca outbox, sehingga aggregate lain tetap berjalan.
+//   - Event yang gagal MaxAttempts kali menjadi dead: berhenti dicoba dan tidak lagi menahan aggregate-nya
+//     (urutan untuk aggregate itu dikorbankan agar tidak macet selamanya). Operator memeriksa last_error lalu
+//     mengantrekannya ulang dengan `libctl maintenance run retry-dead-outbox`.
+//
+// MENGAPA hanya satu relay aktif (leader) di antara semua instance?
+//   - Dua relay yang membaca outbox bersamaan bisa mempublikasikan event satu aggregate secara tidak berurutan
+//   - Leader dipilih lewat GET_LOCK MySQL pada koneksi khusus: jika instance mati, koneksinya putus, lock lepas,
+//     dan instance lain mengambil alih pada poll berikutnya
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/clock"
+	"github.com/Ar1veeee/library-api/internal/event"
+	"github.com/Ar1veeee/library-api/internal/metrics"
+	"github.com/Ar1veeee/library-api/internal/model"
+	"github.com/Ar1veeee/library-api/internal/repository"
+)
+
+// lockName adalah nama lock MySQL untuk pemilihan leader relay.
+const lockName = "library_api_outbox_relay"
+
+// batchSize adalah jumlah event yang dibaca dalam satu putaran.
+const batchSize = 100
+
+// maxBackoff membatasi jeda retry event yang gagal. Lebih pendek dari webhook karena sink adalah infrastruktur
+// sendiri: begitu pulih, event yang tertahan harus segera mengalir lagi.
+const maxBackoff = 5 * time.Minute
+
+type Relay struct {
+	db           *sql.DB
+	repo         *repository.OutboxRepository
+	clock        clock.Clock
+	sinks        []Sink
+	pollInterval time.Duration
+	maxAttempts  int
+	log          *slog.Logger
+}
+
+func NewRelay(db *sql.DB, repo *repository.OutboxRepository, clock clock.Clock, sinks []Sink, pollInterval time.Duration, maxAttempts int, log *slog.Logger) *Relay {
+	return &Relay{
+		db:           db,
+		repo:         repo,
+		clock:        clock,
+		sinks:        sinks,
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+		log:          log,
+	}
+}
+
+// Run mempublikasikan event sampai ctx dibatalkan. Instance yang bukan leader mencoba mengambil alih
+// setiap PollInterval.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	var conn *sql.Conn
+	defer func() {
+		if conn != nil {
+			r.release(conn)
+		}
+	}()
+
+	for {
+		if conn == nil {
+			conn = r.acquire(ctx)
+		} else if !r.stillLeader(ctx, conn) {
+			r.release(conn)
+			conn = nil
+		}
+
+		if conn != nil {
+			// Batch yang seluruhnya terpublikasi berarti outbox mungkin masih berisi; lanjutkan tanpa menunggu poll.
+			for ctx.Err() == nil && r.relayBatch(ctx) == batchSize {
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// acquire mencoba menjadi leader. Koneksi dikembalikan (dan harus tetap dipegang) jika berhasil, nil jika tidak.
+func (r *Relay) acquire(ctx context.Context) *sql.Conn {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Error("outbox relay failed to get connection", "error", err)
+		}
+		return nil
+	}
+
+	// Timeout 0: tidak menunggu; instance lain yang sedang menjadi leader dibiarkan bekerja.
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, 0)`, lockName).Scan(&acquired); err != nil || acquired.Int64 != 1 {
+		if err != nil && ctx.Err() == nil {
+			r.log.Error("outbox relay failed to acquire lock", "error", err)
+		}
+		conn.Close()
+		return nil
+	}
+
+	r.log.Info("outbox relay is now the leader")
+	return conn
+}
+
+// stillLeader memastikan lock masih dipegang koneksi ini, misalnya setelah koneksi sempat putus dan
+// instance lain mengambil alih.
+func (r *Relay) stillLeader(ctx context.Context, conn *sql.Conn) bool {
+	var owned sql.NullBool
+	err := conn.QueryRowContext(ctx, `SELECT IS_USED_LOCK(?) = CONNECTION_ID()`, lockName).Scan(&owned)
+	if err == nil && owned.Bool {
+		return true
+	}
+	if ctx.Err() == nil {
+		r.log.Warn("outbox relay lost leadership", "error", err)
+	}
+	return false
+}
+
+func (r *Relay) release(conn *sql.Conn) {
+	// context.Background: lock tetap dilepas walaupun ctx sudah dibatalkan saat shutdown.
+	_, _ = conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, lockName)
+	conn.Close()
+}
+
+// relayBatch mempublikasikan satu batch dan mengembalikan jumlah event yang berhasil dipublikasikan.
+//
+// Batch hanya berisi event yang jatuh tempo dan tidak tertahan (lihat ListUnpublished). Event yang gagal di batch
+// ini menahan event berikutnya dari aggregate yang sama (blocked); aggregate lain tetap berjalan, sehingga satu
+// event bermasalah tidak menghentikan seluruh outbox.
+func (r *Relay) relayBatch(ctx context.Context) int {
+	events, err := r.repo.ListUnpublished(ctx, r.clock.Now(), batchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Error("failed to read outbox", "error", err)
+		}
+		return 0
+	}
+
+	// Publikasi yang sudah dimulai diselesaikan dan dicatat walaupun shutdown, agar tidak diulang tanpa perlu.
+	publishCtx := context.WithoutCancel(ctx)
+	blocked := make(map[string]bool)
+	published := 0
+
+	for _, row := range events {
+		if ctx.Err() != nil {
+			break
+		}
+		if blocked[row.Aggregate] {
+			continue
+		}
+
+		if err := r.publish(publishCtx, row); err != nil {
+			blocked[row.Aggregate] = true
+			attempt := row.Attempts + 1
+			dead := attempt >= r.maxAttempts
+			nextAttemptAt := r.clock.Now()
+			if !dead {
+				nextAttemptAt = nextAttemptAt.Add(r.backoff(attempt))
+			}
+			if markErr := r.repo.MarkFailed(publishCtx, row.ID, err.Error(), nextAttemptAt, dead); markErr != nil {
+				r.log.Error("failed to record outbox failure", "outbox_id", row.ID, "error", markErr)
+			}
+			if dead {
+				r.log.Error("outbox event publish failed too many times, marked dead",
+					"event_id", row.EventID,
+					"event_type", row.EventType,
+					"aggregate", row.Aggregate,
+					"attempts", attempt,
+					"error", err,
+				)
+				continue
+			}
+			r.log.Warn("outbox event publish failed, will retry",
+				"event_id", row.EventID,
+				"event_type", row.EventType,
+				"aggregate", row.Aggregate,
+				"attempts", attempt,
+				"next_attempt_at", nextAttemptAt,
+				"error", err,
+			)
+			continue
+		}
+
+		if err := r.repo.MarkPublished(publishCtx, row.ID, r.clock.Now()); err != nil {
+			// Event akan dipublikasikan ulang (at-least-once); aggregate ditahan agar urutan tetap terjaga.
+			blocked[row.Aggregate] = true
+			r.log.Error("failed to mark outbox event published", "outbox_id", row.ID, "error", err)
+			continue
+		}
+		published++
+	}
+
+	return published
+}
+
+// publish meneruskan event ke semua sink secara berurutan. Jika satu sink gagal, event diulang ke semua sink
+// pada percobaan berikutnya (sink yang sudah berhasil menerima duplikat).
+func (r *Relay) publish(ctx context.Context, row model.OutboxEvent) error {
+	e, err := event.Decode(row.Payload, row.Aggregate)
+	if err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+
+	for _, sink := range r.sinks {
+		err := sink.Publisher.Publish(ctx, e)
+		metrics.ObserveOutboxPublish(sink.Name, err)
+		if err != nil {
+			return fmt.Errorf("sink %s: %w", sink.Name, err)
+		}
+	}
+	return nil
+}
+
+// backoff: PollInterval, 2×, 4×, ... dibatasi maxBackoff.
+func (r *Relay) backoff(attempt int) time.Duration {
+	delay := r.pollInterval
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,69 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/Ar1veeee/library-api/internal/broker"
+	"github.com/Ar1veeee/library-api/internal/event"
+)
+
+// Sink adalah tujuan publikasi event. Name dipakai di log dan label metric.
+type Sink struct {
+	Name      string
+	Publisher event.Publisher
+}
+
+// LogSink menulis setiap event sebagai satu baris log, berguna untuk audit dan debugging di development.
+type LogSink struct {
+	log *slog.Logger
+}
+
+func NewLogSink(log *slog.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+func (s *LogSink) Publish(ctx context.Context, events ...event.Event) error {
+	for _, e := range events {
+		data, err := json.Marshal(e.Data)
+		if err != nil {
+			return fmt.Errorf("encode event %s: %w", e.ID, err)
+		}
+		s.log.InfoContext(ctx, "domain event",
+			"event_id", e.ID,
+			"event_type", e.Type,
+			"aggregate", e.Aggregate,
+			"occurred_at", e.OccurredAt,
+			"data", string(data),
+		)
+	}
+	return nil
+}
+
+// BrokerSink mempublikasikan event ke message broker dengan subject "library.<type>" (misalnya
+// "library.loan.created") dan key aggregate, sehingga urutan per aggregate terjaga di broker yang mempartisi per key.
+type BrokerSink struct {
+	broker broker.Broker
+}
+
+func NewBrokerSink(b broker.Broker) *BrokerSink {
+	return &BrokerSink{broker: b}
+}
+
+// SubjectPrefix adalah awalan subject semua event perpustakaan di broker.
+const SubjectPrefix = "library."
+
+func (s *BrokerSink) Publish(ctx context.Context, events ...event.Event) error {
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("encode event %s: %w", e.ID, err)
+		}
+		if err := s.broker.Publish(ctx, SubjectPrefix+string(e.Type), e.Aggregate, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Package ratelimit menyediakan token bucket per key (API key, IP, atau member) yang disimpan di memori.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval adalah jarak minimal antar pembersihan bucket yang sudah penuh kembali.
+const sweepInterval = time.Minute
+
+// Result adalah hasil pengecekan satu request terhadap bucket key-nya.
+type Result struct {
+	Allowed bool
+	// Limit adalah kapasitas bucket (burst), dipakai untuk header RateLimit-Limit.
+	Limit int
+	// Remaining adalah sisa token setelah request ini.
+	Remaining int
+	// Reset adalah waktu sampai bucket terisi penuh kembali.
+	Reset time.Duration
+	// RetryAfter adalah waktu tunggu sampai satu token tersedia (0 jika request diizinkan).
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter adalah kumpulan token bucket dengan rate dan burst yang sama, satu bucket per key.
+// MENGAPA token bucket dan bukan fixed window?
+// - Mengizinkan burst singkat yang wajar (misalnya kiosk yang mengirim beberapa borrow sekaligus)
+// - Tidak ada lonjakan di batas window seperti pada fixed window counter
+//
+// Bucket disimpan di memori per instance, sehingga limit efektif = limit × jumlah instance API.
+type Limiter struct {
+	rate  float64 // token per detik
+	burst int
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// New membuat limiter dengan perMinute token per menit dan kapasitas burst.
+func New(perMinute, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{
+		rate:    float64(perMinute) / 60,
+		burst:   burst,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow mengambil satu token dari bucket milik key.
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	result := Result{Limit: l.burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.durationFor(1 - b.tokens)
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = l.durationFor(float64(l.burst) - b.tokens)
+
+	return result
+}
+
+// durationFor menghitung waktu yang dibutuhkan untuk mengisi sejumlah token.
+func (l *Limiter) durationFor(tokens float64) time.Duration {
+	if l.rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep menghapus bucket yang sudah terisi penuh kembali.
+// Alasan: bucket penuh sama dengan bucket yang belum pernah dibuat, sehingga aman dihapus
+// dan memori tidak tumbuh terus oleh IP yang hanya sekali mengakses API.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package repository
+
+import "strings"
+
+// inClause membuat placeholder "?, ?, ?" dan argumen untuk query WHERE ... IN (...).
+// Dipakai query batch (GetByIDs) yang melayani data loader GraphQL: satu query untuk banyak ID,
+// bukan satu query per ID (masalah N+1).
+func inClause(ids []int) (string, []any) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", "), args
+}
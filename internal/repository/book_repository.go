@@ -21,7 +21,7 @@ func NewBookRepository(db *sql.DB) *BookRepository {
 // Mendukung row-level locking opsional via forUpdate.
 // Digunakan secara internal oleh GetByID (read-only) dan GetByIDForUpdate (with lock).
 func (r *BookRepository) getByID(ctx context.Context, tx *sql.Tx, bookID int, forUpdate bool) (*model.Book, error) {
-	query := `SELECT id, title, author, stock FROM books WHERE id = ?`
+	query := `SELECT id, title, author, total_copies, stock FROM books WHERE id = ?`
 	if forUpdate {
 		query += ` FOR UPDATE`
 	}
@@ -34,11 +34,11 @@ func (r *BookRepository) getByID(ctx context.Context, tx *sql.Tx, bookID int, fo
 
 	if tx != nil {
 		err = tx.QueryRowContext(ctx, query, bookID).Scan(
-			&book.ID, &book.Title, &book.Author, &book.Stock,
+			&book.ID, &book.Title, &book.Author, &book.TotalCopies, &book.Stock,
 		)
 	} else {
 		err = r.db.QueryRowContext(ctx, query, bookID).Scan(
-			&book.ID, &book.Title, &book.Author, &book.Stock,
+			&book.ID, &book.Title, &book.Author, &book.TotalCopies, &book.Stock,
 		)
 	}
 
@@ -54,16 +54,25 @@ func (r *BookRepository) getByID(ctx context.Context, tx *sql.Tx, bookID int, fo
 
 // GetByID mengambil detail buku (tanpa locking).
 func (r *BookRepository) GetByID(ctx context.Context, bookID int) (*model.Book, error) {
+	ctx, span := tracer.Start(ctx, "BookRepository.GetByID")
+	defer span.End()
+
 	return r.getByID(ctx, nil, bookID, false)
 }
 
 // GetByIDForUpdate mengambil buku dengan row lock, khusus untuk update stock dalam transaksi.
 func (r *BookRepository) GetByIDForUpdate(ctx context.Context, tx *sql.Tx, bookID int) (*model.Book, error) {
+	ctx, span := tracer.Start(ctx, "BookRepository.GetByIDForUpdate")
+	defer span.End()
+
 	return r.getByID(ctx, tx, bookID, true)
 }
 
 func (r *BookRepository) GetAll(ctx context.Context) ([]model.Book, error) {
-	query := `SELECT id, title, author, stock FROM books ORDER BY title`
+	ctx, span := tracer.Start(ctx, "BookRepository.GetAll")
+	defer span.End()
+
+	query := `SELECT id, title, author, total_copies, stock FROM books ORDER BY title`
 
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
@@ -81,7 +90,7 @@ func (r *BookRepository) GetAll(ctx context.Context) ([]model.Book, error) {
 
 		// Scan langsung ke field struct tanpa pointer sementara.
 		// Alasan: lebih ringkas dan performanya cukup baik untuk jumlah data yang relatif kecil (katalog buku perpustakaan).
-		if err := rows.Scan(&book.ID, &book.Title, &book.Author, &book.Stock); err != nil {
+		if err := rows.Scan(&book.ID, &book.Title, &book.Author, &book.TotalCopies, &book.Stock); err != nil {
 			return nil, err
 		}
 
@@ -92,6 +101,36 @@ func (r *BookRepository) GetAll(ctx context.Context) ([]model.Book, error) {
 	return books, rows.Err()
 }
 
+// GetByIDs mengambil banyak buku sekaligus; ID yang tidak ada tidak muncul di hasil (bukan error).
+func (r *BookRepository) GetByIDs(ctx context.Context, bookIDs []int) ([]model.Book, error) {
+	ctx, span := tracer.Start(ctx, "BookRepository.GetByIDs")
+	defer span.End()
+
+	if len(bookIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(bookIDs)
+	query := `SELECT id, title, author, total_copies, stock FROM books WHERE id IN (` + placeholders + `)`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var books []model.Book
+	for rows.Next() {
+		var book model.Book
+		if err := rows.Scan(&book.ID, &book.Title, &book.Author, &book.TotalCopies, &book.Stock); err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
 // adjustStock mengubah stok buku secara atomic (+ untuk tambah, - untuk kurang).
 // Pendekatan UPDATE langsung lebih aman dari race condition daripada SELECT lalu UPDATE.
 func (r *BookRepository) adjustStock(ctx context.Context, tx *sql.Tx, bookID int, amount int) error {
@@ -135,16 +174,150 @@ func (r *BookRepository) adjustStock(ctx context.Context, tx *sql.Tx, bookID int
 		return fmt.Errorf("buku dengan ID %d tidak ditemukan: %w", bookID, sql.ErrNoRows)
 	}
 
-	return nil
+	// Stok dibaca ulang di transaksi yang sama; row buku masih ter-lock oleh UPDATE di atas,
+	// sehingga nilainya pasti hasil perubahan ini.
+	var stock int
+	if err := tx.QueryRowContext(ctx, `SELECT stock FROM books WHERE id = ?`, bookID).Scan(&stock); err != nil {
+		return err
+	}
+
+	return recordStockChange(ctx, tx, bookID, stock, amount)
+}
+
+// recordStockChange mencatat perubahan stok ke stock_changes (sumber stream SSE) di dalam transaksi perubahannya.
+// MENGAPA di repository, bukan di service?
+// - Setiap jalur yang mengubah stok otomatis tercatat, tanpa bergantung pada pemanggil yang ingat
+// - Rollback ikut membatalkan catatannya, sehingga stream tidak pernah menampilkan stok yang tidak jadi berubah
+//
+// INSERT ... VALUES biasa (bukan INSERT ... SELECT) karena INSERT ... SELECT bisa menyisakan celah auto-increment
+// yang membuat stream menunggu celah tersebut (lihat internal/stockstream).
+func recordStockChange(ctx context.Context, tx *sql.Tx, bookID, stock, delta int) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO stock_changes (book_id, stock, delta) VALUES (?, ?, ?)`, bookID, stock, delta)
+	return err
 }
 
 // DecrementStock mengurangi stok buku dalam transaction peminjaman
 func (r *BookRepository) DecrementStock(ctx context.Context, tx *sql.Tx, bookID int) error {
+	ctx, span := tracer.Start(ctx, "BookRepository.DecrementStock")
+	defer span.End()
+
 	return r.adjustStock(ctx, tx, bookID, -1)
 }
 
 // IncrementStock mengurangi stok buku saat pengembalian
 // (Catatan: komentar fungsi salah, seharusnya "menambah stok")
 func (r *BookRepository) IncrementStock(ctx context.Context, tx *sql.Tx, bookID int) error {
+	ctx, span := tracer.Start(ctx, "BookRepository.IncrementStock")
+	defer span.End()
+
 	return r.adjustStock(ctx, tx, bookID, +1)
 }
+
+// Create menambahkan buku baru ke katalog dalam transaksi.
+func (r *BookRepository) Create(ctx context.Context, tx *sql.Tx, book *model.Book) (int64, error) {
+	ctx, span := tracer.Start(ctx, "BookRepository.Create")
+	defer span.End()
+
+	query := `INSERT INTO books (title, author, total_copies, stock) VALUES (?, ?, ?, ?)`
+
+	result, err := tx.ExecContext(ctx, query, book.Title, book.Author, book.TotalCopies, book.Stock)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+// CreateWithID menambahkan buku dengan ID yang sudah ditentukan, dipakai import katalog hasil export
+// ke database yang belum berisi buku tersebut. AUTO_INCREMENT MySQL otomatis melompati ID yang disisipkan,
+// sehingga Create berikutnya tidak bentrok.
+func (r *BookRepository) CreateWithID(ctx context.Context, tx *sql.Tx, book *model.Book) error {
+	ctx, span := tracer.Start(ctx, "BookRepository.CreateWithID")
+	defer span.End()
+
+	query := `INSERT INTO books (id, title, author, total_copies, stock) VALUES (?, ?, ?, ?, ?)`
+
+	_, err := tx.ExecContext(ctx, query, book.ID, book.Title, book.Author, book.TotalCopies, book.Stock)
+	return err
+}
+
+// UpdateCatalog memperbarui data katalog (judul, penulis, jumlah eksemplar) tanpa menyentuh stok.
+// Alasan stok tidak ikut di-update di sini: stok adalah turunan dari total_copies dan pinjaman aktif,
+// sehingga penyesuaiannya dilakukan lewat SetStock setelah dihitung ulang.
+func (r *BookRepository) UpdateCatalog(ctx context.Context, tx *sql.Tx, book *model.Book) error {
+	ctx, span := tracer.Start(ctx, "BookRepository.UpdateCatalog")
+	defer span.End()
+
+	query := `UPDATE books SET title = ?, author = ?, total_copies = ? WHERE id = ?`
+
+	// RowsAffected tidak diperiksa karena MySQL mengembalikan 0 jika nilainya tidak berubah.
+	// Keberadaan buku sudah dipastikan pemanggil lewat GetByIDForUpdate.
+	_, err := tx.ExecContext(ctx, query, book.Title, book.Author, book.TotalCopies, book.ID)
+	return err
+}
+
+// GetStockLedgerForUpdate mengambil stok tersimpan beserta jumlah pinjaman aktif untuk setiap buku (atau satu buku jika bookID > 0).
+// MENGAPA FOR UPDATE?
+// - Perhitungan ulang stok tidak boleh berjalan bersamaan dengan borrow/return yang mengubah stok buku yang sama.
+// - Row lock pada books membuat borrow/return menunggu sampai koreksi stok di-commit.
+func (r *BookRepository) GetStockLedgerForUpdate(ctx context.Context, tx *sql.Tx, bookID int) ([]model.StockLedger, error) {
+	ctx, span := tracer.Start(ctx, "BookRepository.GetStockLedgerForUpdate")
+	defer span.End()
+
+	query := `
+       SELECT b.id, b.title, b.total_copies, b.stock,
+              (SELECT COUNT(*) FROM loans l WHERE l.book_id = b.id AND l.returned_at IS NULL)
+       FROM books b
+    `
+	var args []interface{}
+	if bookID > 0 {
+		query += ` WHERE b.id = ?`
+		args = append(args, bookID)
+	}
+	query += ` ORDER BY b.id FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ledger []model.StockLedger
+	for rows.Next() {
+		var entry model.StockLedger
+		if err := rows.Scan(&entry.BookID, &entry.Title, &entry.TotalCopies, &entry.Stock, &entry.ActiveLoans); err != nil {
+			return nil, err
+		}
+		ledger = append(ledger, entry)
+	}
+
+	return ledger, rows.Err()
+}
+
+// SetStock menimpa stok buku dengan nilai absolut hasil perhitungan ulang.
+// Hanya dipakai untuk koreksi administratif; alur borrow/return tetap memakai adjustStock yang atomic.
+func (r *BookRepository) SetStock(ctx context.Context, tx *sql.Tx, bookID int, stock int) error {
+	ctx, span := tracer.Start(ctx, "BookRepository.SetStock")
+	defer span.End()
+
+	// Stok lama sudah di-lock oleh GetStockLedgerForUpdate, sehingga selisihnya tidak berubah sampai commit.
+	var oldStock int
+	if err := tx.QueryRowContext(ctx, `SELECT stock FROM books WHERE id = ?`, bookID).Scan(&oldStock); err != nil {
+		return err
+	}
+	query := `UPDATE books SET stock = ? WHERE id = ?`
+
+	if _, err := tx.ExecContext(ctx, query, stock, bookID); err != nil {
+		return err
+	}
+
+	return recordStockChange(ctx, tx, bookID, stock, stock-oldStock)
+}
+
+// CountOutOfStock menghitung judul buku yang stoknya habis (untuk metric).
+// Memanfaatkan idx_stock sehingga tetap ringan walaupun dipanggil setiap scrape.
+func (r *BookRepository) CountOutOfStock(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM books WHERE stock <= 0`).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Ar1veeee/library-api/internal/model"
+)
+
+type FineRepository struct {
+	db *sql.DB
+}
+
+func NewFineRepository(db *sql.DB) *FineRepository {
+	return &FineRepository{db: db}
+}
+
+// GetByMemberIDs mengambil denda banyak member sekaligus (terbaru di atas per member) untuk data loader GraphQL.
+func (r *FineRepository) GetByMemberIDs(ctx context.Context, memberIDs []int) ([]model.Fine, error) {
+	ctx, span := tracer.Start(ctx, "FineRepository.GetByMemberIDs")
+	defer span.End()
+
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(memberIDs)
+	query := `
+          SELECT id, loan_id, member_id, book_id, days_overdue, amount, created_at, paid_at
+          FROM fines
+          WHERE member_id IN (` + placeholders + `)
+          ORDER BY member_id, id DESC
+       `
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fines []model.Fine
+	for rows.Next() {
+		var fine model.Fine
+		if err := rows.Scan(
+			&fine.ID, &fine.LoanID, &fine.MemberID, &fine.BookID, &fine.DaysOverdue, &fine.Amount, &fine.CreatedAt, &fine.PaidAt,
+		); err != nil {
+			return nil, err
+		}
+		fines = append(fines, fine)
+	}
+
+	return fines, rows.Err()
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Ar1veeee/library-api/internal/model"
+)
+
+type HoldRepository struct {
+	db *sql.DB
+}
+
+func NewHoldRepository(db *sql.DB) *HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+// GetByMemberIDs mengambil reservasi banyak member sekaligus (terbaru di atas per member) untuk data loader GraphQL,
+// beserta posisi antrean (Ahead) untuk reservasi yang masih waiting.
+func (r *HoldRepository) GetByMemberIDs(ctx context.Context, memberIDs []int) ([]model.Hold, error) {
+	ctx, span := tracer.Start(ctx, "HoldRepository.GetByMemberIDs")
+	defer span.End()
+
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(memberIDs)
+	// Subquery posisi memakai index idx_book_queue; hanya dijalankan untuk reservasi milik member yang diminta.
+	query := `
+          SELECT h.id, h.member_id, h.book_id, h.status, h.created_at, h.closed_at,
+                 (SELECT COUNT(*) FROM holds w WHERE w.book_id = h.book_id AND w.status = ? AND w.id < h.id)
+          FROM holds h
+          WHERE h.member_id IN (` + placeholders + `)
+          ORDER BY h.member_id, h.id DESC
+       `
+
+	rows, err := r.db.QueryContext(ctx, query, append([]any{model.HoldWaiting}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []model.Hold
+	for rows.Next() {
+		var hold model.Hold
+		if err := rows.Scan(
+			&hold.ID, &hold.MemberID, &hold.BookID, &hold.Status, &hold.CreatedAt, &hold.ClosedAt, &hold.Ahead,
+		); err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+
+	return holds, rows.Err()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/Ar1veeee/library-api/internal/model"
 )
@@ -25,6 +26,9 @@ func NewLoanRepository(db *sql.DB) *LoanRepository {
 //   - FOR UPDATE pada query COUNT memastikan transaksi kedua menunggu hingga transaksi pertama commit/rollback,
 //     sehingga kuota selalu konsisten bahkan pada concurrency tinggi.
 func (r *LoanRepository) CountActiveLoansByMember(ctx context.Context, tx *sql.Tx, memberID int) (int, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.CountActiveLoansByMember")
+	defer span.End()
+
 	query := `
        SELECT count(*)
        FROM loans
@@ -42,6 +46,9 @@ func (r *LoanRepository) CountActiveLoansByMember(ctx context.Context, tx *sql.T
 // Locking tidak diperlukan karena tidak mengubah data dan hasilnya hanya untuk pencegahan logika bisnis,
 // bukan untuk menjaga integritas kuota/stock.
 func (r *LoanRepository) CheckActiveLoanExists(ctx context.Context, tx *sql.Tx, memberID, bookID int) (bool, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.CheckActiveLoanExists")
+	defer span.End()
+
 	query := `
        SELECT EXISTS(
           SELECT 1
@@ -58,22 +65,38 @@ func (r *LoanRepository) CheckActiveLoanExists(ctx context.Context, tx *sql.Tx,
 	return exists, err
 }
 
-// Create membuat record peminjaman baru
-func (r *LoanRepository) Create(ctx context.Context, tx *sql.Tx, memberID, bookID int) (int64, error) {
-	query := `INSERT INTO loans (member_id, book_id, borrowed_at) VALUES (?, ?, NOW())`
+// Create membuat record peminjaman baru dengan borrowed_at = now.
+func (r *LoanRepository) Create(ctx context.Context, tx *sql.Tx, memberID, bookID int, now time.Time) (*model.Loan, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.Create")
+	defer span.End()
+
+	query := `INSERT INTO loans (member_id, book_id, borrowed_at) VALUES (?, ?, ?)`
+
+	// Alasan waktu dikirim dari service (clock.Clock), bukan NOW() di database:
+	// - Satu sumber waktu untuk semua logika pinjam (jatuh tempo, overdue), sehingga bisa diuji & disimulasikan
+	// - Perbedaan jam antar instance dijaga oleh NTP; selisih milidetik tidak berpengaruh ke masa pinjam harian
+	//
+	// Truncate ke detik karena kolom TIMESTAMP tanpa fractional seconds membulatkan nilainya;
+	// dengan begitu nilai yang dikembalikan sama persis dengan yang tersimpan dan muncul di riwayat pinjaman.
+	borrowedAt := now.Truncate(time.Second)
+
+	result, err := tx.ExecContext(ctx, query, memberID, bookID, borrowedAt)
+	if err != nil {
+		return nil, err
+	}
 
-	// Alasan menggunakan NOW() di sisi database:
-	// - Konsistensi waktu: semua server menggunakan waktu database yang sama, menghindari perbedaan clock antar instance.
-	// - Atomic dengan insert, sehingga tidak ada race pada timestamp.
-	result, err := tx.ExecContext(ctx, query, memberID, bookID)
+	loanID, err := result.LastInsertId()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return result.LastInsertId()
+	return &model.Loan{ID: int(loanID), MemberID: memberID, BookID: bookID, BorrowedAt: borrowedAt}, nil
 }
 
 func (r *LoanRepository) GetActiveLoanByMemberAndBook(ctx context.Context, tx *sql.Tx, memberID, bookID int) (*model.Loan, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.GetActiveLoanByMemberAndBook")
+	defer span.End()
+
 	query := `
        SELECT id, member_id, book_id, borrowed_at, returned_at
        FROM loans
@@ -100,17 +123,47 @@ func (r *LoanRepository) GetActiveLoanByMemberAndBook(ctx context.Context, tx *s
 	return &loan, err
 }
 
-func (r *LoanRepository) MarkAsReturned(ctx context.Context, tx *sql.Tx, loanID int) error {
-	query := `UPDATE loans SET returned_at = NOW() WHERE id = ?`
+// GetByIDForUpdate mengambil loan berdasarkan ID dengan row lock.
+// Digunakan untuk pengembalian paksa oleh admin, di mana member & buku tidak diketahui dari request.
+func (r *LoanRepository) GetByIDForUpdate(ctx context.Context, tx *sql.Tx, loanID int) (*model.Loan, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.GetByIDForUpdate")
+	defer span.End()
+
+	query := `
+       SELECT id, member_id, book_id, borrowed_at, returned_at
+       FROM loans
+       WHERE id = ?
+       FOR UPDATE
+    `
+
+	var loan model.Loan
+	err := tx.QueryRowContext(ctx, query, loanID).Scan(
+		&loan.ID, &loan.MemberID, &loan.BookID, &loan.BorrowedAt, &loan.ReturnedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
 
-	// Alasan menggunakan NOW() di database dan tidak menyertakan returned_at IS NULL di WHERE:
+	return &loan, err
+}
+
+func (r *LoanRepository) MarkAsReturned(ctx context.Context, tx *sql.Tx, loanID int, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "LoanRepository.MarkAsReturned")
+	defer span.End()
+
+	query := `UPDATE loans SET returned_at = ? WHERE id = ?`
+
+	// Alasan tidak menyertakan returned_at IS NULL di WHERE:
 	// - Jika loan sudah returned, update tetap berhasil tapi tidak mengubah apa-apa.
 	// - Menghindari error "not found" yang tidak perlu. Operasi return bersifat idempotent dan aman diulang.
-	_, err := tx.ExecContext(ctx, query, loanID)
+	_, err := tx.ExecContext(ctx, query, now.Truncate(time.Second), loanID)
 	return err
 }
 
 func (r *LoanRepository) GetByMemberID(ctx context.Context, memberID int) ([]model.Loan, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.GetByMemberID")
+	defer span.End()
+
 	query := `
           SELECT l.id, l.member_id, l.book_id, l.borrowed_at, l.returned_at, b.title, b.author
           FROM loans l
@@ -146,3 +199,120 @@ func (r *LoanRepository) GetByMemberID(ctx context.Context, memberID int) ([]mod
 	// Alasan: rows.Err() dapat mengembalikan error yang terjadi selama iterasi (misalnya koneksi terputus di tengah scan).
 	return loans, rows.Err()
 }
+
+// GetByMemberIDs mengambil riwayat pinjaman banyak member sekaligus, terbaru di atas per member.
+// MENGAPA tanpa JOIN ke books seperti GetByMemberID?
+//   - Dipakai data loader GraphQL: detail buku hanya diambil jika query memintanya, lewat loader buku
+//     yang mengumpulkan semua book_id menjadi satu query (bukan satu query per pinjaman)
+//   - BookTitle/BookAuthor sengaja dibiarkan kosong
+func (r *LoanRepository) GetByMemberIDs(ctx context.Context, memberIDs []int) ([]model.Loan, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.GetByMemberIDs")
+	defer span.End()
+
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(memberIDs)
+	query := `
+          SELECT id, member_id, book_id, borrowed_at, returned_at
+          FROM loans
+          WHERE member_id IN (` + placeholders + `)
+          ORDER BY member_id, borrowed_at DESC
+       `
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loans []model.Loan
+	for rows.Next() {
+		var loan model.Loan
+		if err := rows.Scan(&loan.ID, &loan.MemberID, &loan.BookID, &loan.BorrowedAt, &loan.ReturnedAt); err != nil {
+			return nil, err
+		}
+		loans = append(loans, loan)
+	}
+
+	return loans, rows.Err()
+}
+
+// GetOverdue mengambil pinjaman aktif yang dipinjam sebelum borrowedBefore (sekarang dikurangi masa pinjam).
+// Batas waktu dihitung oleh service dari clock yang sama dengan yang mengisi borrowed_at.
+func (r *LoanRepository) GetOverdue(ctx context.Context, borrowedBefore time.Time) ([]model.Loan, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.GetOverdue")
+	defer span.End()
+
+	return r.getOverdue(ctx, borrowedBefore, false)
+}
+
+// GetOverdueUnnotified sama dengan GetOverdue, tetapi hanya pinjaman yang belum dikirimi event loan.overdue.
+func (r *LoanRepository) GetOverdueUnnotified(ctx context.Context, borrowedBefore time.Time) ([]model.Loan, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.GetOverdueUnnotified")
+	defer span.End()
+
+	return r.getOverdue(ctx, borrowedBefore, true)
+}
+
+func (r *LoanRepository) getOverdue(ctx context.Context, borrowedBefore time.Time, onlyUnnotified bool) ([]model.Loan, error) {
+	query := `
+          SELECT l.id, l.member_id, l.book_id, l.borrowed_at, l.returned_at, b.title, b.author, m.name, m.email
+          FROM loans l
+          JOIN books b ON l.book_id = b.id
+          JOIN members m ON l.member_id = m.id
+          WHERE l.returned_at IS NULL AND l.borrowed_at < ?
+       `
+	if onlyUnnotified {
+		query += ` AND l.overdue_notified_at IS NULL`
+	}
+	query += ` ORDER BY l.borrowed_at`
+
+	rows, err := r.db.QueryContext(ctx, query, borrowedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loans []model.Loan
+	for rows.Next() {
+		var loan model.Loan
+		if err := rows.Scan(
+			&loan.ID, &loan.MemberID, &loan.BookID, &loan.BorrowedAt, &loan.ReturnedAt,
+			&loan.BookTitle, &loan.BookAuthor, &loan.MemberName, &loan.MemberEmail,
+		); err != nil {
+			return nil, err
+		}
+		loans = append(loans, loan)
+	}
+
+	return loans, rows.Err()
+}
+
+// MarkOverdueNotified menandai pinjaman sudah dikirimi event loan.overdue, di dalam transaksi yang sama
+// dengan penulisan event-nya ke outbox.
+// Mengembalikan false jika instance lain sudah menandainya lebih dulu (atau buku sudah dikembalikan),
+// sehingga setiap pinjaman hanya dinotifikasi sekali walaupun pemindaian berjalan di beberapa instance.
+func (r *LoanRepository) MarkOverdueNotified(ctx context.Context, tx *sql.Tx, loanID int, now time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "LoanRepository.MarkOverdueNotified")
+	defer span.End()
+
+	result, err := tx.ExecContext(ctx, `
+          UPDATE loans SET overdue_notified_at = ?
+          WHERE id = ? AND overdue_notified_at IS NULL AND returned_at IS NULL
+       `, now.Truncate(time.Second), loanID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+// CountActive menghitung seluruh pinjaman yang belum dikembalikan (untuk metric).
+func (r *LoanRepository) CountActive(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM loans WHERE returned_at IS NULL`).Scan(&count)
+	return count, err
+}
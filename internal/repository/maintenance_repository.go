@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type MaintenanceRepository struct {
+	db *sql.DB
+}
+
+func NewMaintenanceRepository(db *sql.DB) *MaintenanceRepository {
+	return &MaintenanceRepository{db: db}
+}
+
+// AnalyzeTables memperbarui statistik index InnoDB untuk tabel utama.
+// Alasan: setelah import katalog atau pengembalian massal, statistik index bisa basi
+// sehingga optimizer salah memilih index (misalnya idx_member_active pada cek kuota).
+func (r *MaintenanceRepository) AnalyzeTables(ctx context.Context) error {
+	// ANALYZE TABLE mengembalikan result set, sehingga dipanggil via QueryContext dan hasilnya dibuang.
+	rows, err := r.db.QueryContext(ctx, `ANALYZE TABLE books, members, loans`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+
+	return rows.Err()
+}
+
+// PruneOutbox menghapus event outbox yang sudah dipublikasikan sebelum waktu tertentu dan mengembalikan jumlahnya.
+// Event yang belum dipublikasikan tidak pernah dihapus, berapa pun umurnya.
+func (r *MaintenanceRepository) PruneOutbox(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM outbox_events WHERE published_at IS NOT NULL AND published_at < ?`,
+		publishedBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// PruneStockChanges menghapus riwayat perubahan stok sebelum waktu tertentu dan mengembalikan jumlahnya.
+// Client stream yang terputus lebih lama dari masa simpan tidak menerima perubahan yang terhapus, tetapi event
+// berikutnya tetap membawa stok absolut yang benar.
+func (r *MaintenanceRepository) PruneStockChanges(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM stock_changes WHERE created_at < ?`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
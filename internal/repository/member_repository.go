@@ -4,10 +4,19 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/Ar1veeee/library-api/internal/model"
+	"github.com/go-sql-driver/mysql"
 )
 
+// mysqlErrDuplicateEntry adalah kode error MySQL untuk pelanggaran UNIQUE constraint.
+const mysqlErrDuplicateEntry = 1062
+
+// ErrDuplicateEntry dikembalikan saat insert melanggar unique constraint (misalnya email member sudah terdaftar).
+// Alasan membungkus error driver menjadi sentinel: service layer tidak perlu bergantung ke package driver MySQL.
+var ErrDuplicateEntry = errors.New("duplicate entry")
+
 type MemberRepository struct {
 	db *sql.DB
 }
@@ -19,7 +28,10 @@ func NewMemberRepository(db *sql.DB) *MemberRepository {
 // GetByID mengambil data member berdasarkan ID.
 // Mengembalikan (*model.Member, nil) jika ditemukan, (nil, nil) jika tidak ada, dan error jika terjadi kegagalan query.
 func (r *MemberRepository) GetByID(ctx context.Context, memberID int) (*model.Member, error) {
-	query := `SELECT id, name, email FROM members WHERE id = ?`
+	ctx, span := tracer.Start(ctx, "MemberRepository.GetByID")
+	defer span.End()
+
+	query := `SELECT id, name, email, COALESCE(language, '') FROM members WHERE id = ?`
 
 	var member model.Member
 
@@ -27,7 +39,7 @@ func (r *MemberRepository) GetByID(ctx context.Context, memberID int) (*model.Me
 	// Operasi ini pure read-only dan tidak memerlukan konsistensi transaksional ketat.
 	// Menjaga performa tinggi dan overhead rendah untuk operasi yang sering dipanggil (misalnya validasi member saat borrow).
 	err := r.db.QueryRowContext(ctx, query, memberID).Scan(
-		&member.ID, &member.Name, &member.Email,
+		&member.ID, &member.Name, &member.Email, &member.Language,
 	)
 
 	// Alasan mengembalikan (nil, nil) bukannya error khusus saat sql.ErrNoRows:
@@ -39,3 +51,53 @@ func (r *MemberRepository) GetByID(ctx context.Context, memberID int) (*model.Me
 
 	return &member, err
 }
+
+// GetByIDs mengambil banyak member sekaligus; ID yang tidak ada tidak muncul di hasil (bukan error).
+func (r *MemberRepository) GetByIDs(ctx context.Context, memberIDs []int) ([]model.Member, error) {
+	ctx, span := tracer.Start(ctx, "MemberRepository.GetByIDs")
+	defer span.End()
+
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(memberIDs)
+	query := `SELECT id, name, email, COALESCE(language, '') FROM members WHERE id IN (` + placeholders + `)`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []model.Member
+	for rows.Next() {
+		var member model.Member
+		if err := rows.Scan(&member.ID, &member.Name, &member.Email, &member.Language); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// Create menyimpan member baru dan mengembalikan ID-nya.
+func (r *MemberRepository) Create(ctx context.Context, member *model.Member) (int64, error) {
+	ctx, span := tracer.Start(ctx, "MemberRepository.Create")
+	defer span.End()
+
+	query := `INSERT INTO members (name, email, language) VALUES (?, ?, NULLIF(?, ''))`
+
+	// NULLIF: member tanpa preferensi disimpan sebagai NULL, bukan string kosong.
+	result, err := r.db.ExecContext(ctx, query, member.Name, member.Email, member.Language)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+			return 0, fmt.Errorf("member dengan email %s sudah ada: %w", member.Email, ErrDuplicateEntry)
+		}
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/model"
+)
+
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Insert menulis event ke outbox di dalam transaksi pemanggil.
+// MENGAPA wajib memakai tx yang sama dengan perubahan data?
+//   - Event dan perubahan data ikut commit atau rollback bersama: tidak ada event untuk peminjaman yang batal,
+//     dan tidak ada peminjaman tanpa event walaupun proses mati tepat setelah COMMIT
+func (r *OutboxRepository) Insert(ctx context.Context, tx *sql.Tx, events []model.OutboxEvent) error {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.Insert")
+	defer span.End()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(events))
+	args := make([]any, 0, len(events)*5)
+	for i, e := range events {
+		values[i] = "(?, ?, ?, ?, ?)"
+		args = append(args, e.EventID, e.EventType, e.Aggregate, string(e.Payload), e.NextAttemptAt.Truncate(time.Second))
+	}
+
+	query := `INSERT INTO outbox_events (event_id, event_type, aggregate, payload, next_attempt_at) VALUES ` +
+		strings.Join(values, ", ")
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// ListUnpublished mengambil event yang siap dipublikasikan sesuai urutan tulis.
+//
+// Event milik aggregate yang sedang menunggu retry (ada event lebih awal yang belum jatuh tempo) tidak diambil,
+// sehingga urutan per aggregate terjaga dan aggregate yang tertahan tidak memenuhi batch: event aggregate lain
+// di belakangnya tetap terbaca. Event dead tidak diambil dan tidak menahan aggregate-nya.
+func (r *OutboxRepository) ListUnpublished(ctx context.Context, now time.Time, limit int) ([]model.OutboxEvent, error) {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.ListUnpublished")
+	defer span.End()
+
+	query := `
+          SELECT e.id, e.event_id, e.event_type, e.aggregate, e.payload, e.attempts, e.next_attempt_at
+          FROM outbox_events e
+          WHERE e.published_at IS NULL
+            AND e.dead_at IS NULL
+            AND NOT EXISTS (
+                SELECT 1
+                FROM outbox_events w
+                WHERE w.aggregate = e.aggregate
+                  AND w.id <= e.id
+                  AND w.published_at IS NULL
+                  AND w.dead_at IS NULL
+                  AND w.next_attempt_at > ?
+            )
+          ORDER BY e.id
+          LIMIT ?
+       `
+
+	rows, err := r.db.QueryContext(ctx, query, now.Truncate(time.Second), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []model.OutboxEvent
+	for rows.Next() {
+		var e model.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.EventID, &e.EventType, &e.Aggregate, &e.Payload, &e.Attempts, &e.NextAttemptAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id int64, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.MarkPublished")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE outbox_events SET published_at = ?, attempts = attempts + 1, last_error = NULL WHERE id = ?`,
+		now.Truncate(time.Second), id,
+	)
+	return err
+}
+
+// MarkFailed mencatat publikasi yang gagal: dijadwalkan ulang pada nextAttemptAt, atau berhenti dicoba jika dead
+// (sudah outbox.max_attempts kali, misalnya payload rusak atau sink yang lama mati). Event dead tetap disimpan
+// untuk diperiksa dan bisa diantrekan ulang dengan RetryDead; nextAttemptAt lalu dicatat sebagai dead_at.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.MarkFailed")
+	defer span.End()
+
+	if len(lastError) > 512 {
+		lastError = lastError[:512]
+	}
+
+	nextAttemptAt = nextAttemptAt.Truncate(time.Second)
+	var deadAt *time.Time
+	if dead {
+		deadAt = &nextAttemptAt
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE outbox_events SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?, dead_at = ? WHERE id = ?`,
+		lastError, nextAttemptAt, deadAt, id,
+	)
+	return err
+}
+
+// RetryDead mengantrekan ulang semua event dead dengan jatah percobaan baru dan mengembalikan jumlahnya.
+func (r *OutboxRepository) RetryDead(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE outbox_events SET dead_at = NULL, attempts = 0, next_attempt_at = ? WHERE dead_at IS NOT NULL`,
+		now.Truncate(time.Second),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// CountUnpublished menghitung event yang masih menunggu dipublikasikan (tidak termasuk dead), untuk metric.
+func (r *OutboxRepository) CountUnpublished(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM outbox_events WHERE published_at IS NULL AND dead_at IS NULL`,
+	).Scan(&count)
+	return count, err
+}
+
+// CountDead menghitung event yang berhenti dicoba (untuk metric).
+func (r *OutboxRepository) CountDead(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM outbox_events WHERE dead_at IS NOT NULL`).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Ar1veeee/library-api/internal/model"
+)
+
+// StockChangeRepository membaca log stock_changes untuk stream SSE.
+// Penulisannya ada di BookRepository (recordStockChange) karena harus berada di transaksi perubahan stok.
+type StockChangeRepository struct {
+	db *sql.DB
+}
+
+func NewStockChangeRepository(db *sql.DB) *StockChangeRepository {
+	return &StockChangeRepository{db: db}
+}
+
+// LatestID mengembalikan id perubahan terakhir (0 jika belum ada), titik awal stream yang baru berjalan.
+func (r *StockChangeRepository) LatestID(ctx context.Context) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM stock_changes ORDER BY id DESC LIMIT 1`).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+// ListSince mengambil perubahan dengan afterID < id <= untilID sesuai urutan id, paling banyak limit baris.
+// bookID 0 berarti semua buku; untilID 0 berarti tanpa batas atas.
+func (r *StockChangeRepository) ListSince(ctx context.Context, bookID int, afterID, untilID int64, limit int) ([]model.StockChange, error) {
+	ctx, span := tracer.Start(ctx, "StockChangeRepository.ListSince")
+	defer span.End()
+
+	query := `SELECT id, book_id, stock, delta FROM stock_changes WHERE id > ?`
+	args := []any{afterID}
+	if untilID > 0 {
+		query += ` AND id <= ?`
+		args = append(args, untilID)
+	}
+	if bookID > 0 {
+		query += ` AND book_id = ?`
+		args = append(args, bookID)
+	}
+	query += ` ORDER BY id LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []model.StockChange
+	for rows.Next() {
+		var c model.StockChange
+		if err := rows.Scan(&c.ID, &c.BookID, &c.Stock, &c.Delta); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
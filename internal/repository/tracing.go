@@ -0,0 +1,7 @@
+package repository
+
+import "github.com/Ar1veeee/library-api/internal/tracing"
+
+// tracer membuat span per method repository. Span statement SQL (dari sqlhook.TraceHook) menjadi child
+// span-nya, sehingga di trace terlihat method mana yang menjalankan query FOR UPDATE yang lambat.
+var tracer = tracing.Tracer("github.com/Ar1veeee/library-api/internal/repository")
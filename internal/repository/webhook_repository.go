@@ -0,0 +1,358 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/model"
+)
+
+// Status pengiriman webhook (kolom webhook_deliveries.status).
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+const subscriptionColumns = `id, url, secret, events, active, created_at`
+
+func scanSubscription(scan func(dest ...any) error) (model.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+	var events string
+	err := scan(&sub.ID, &sub.URL, &sub.Secret, &events, &sub.Active, &sub.CreatedAt)
+	sub.Events = strings.Split(events, ",")
+	return sub, err
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, url, secret string, events []string, now time.Time) (*model.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.CreateSubscription")
+	defer span.End()
+
+	createdAt := now.Truncate(time.Second)
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO webhook_subscriptions (url, secret, events, active, created_at) VALUES (?, ?, ?, TRUE, ?)`,
+		url, secret, strings.Join(events, ","), createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WebhookSubscription{
+		ID: int(id), URL: url, Secret: secret, Events: events, Active: true, CreatedAt: createdAt,
+	}, nil
+}
+
+// ListSubscriptions mengambil semua subscription, termasuk yang sudah dinonaktifkan (delivery log-nya tetap bisa dibaca).
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.ListSubscriptions")
+	defer span.End()
+
+	return r.querySubscriptions(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions ORDER BY id`)
+}
+
+// ListActiveSubscriptions mengambil subscription aktif; pencocokan event dilakukan pemanggil.
+// MENGAPA tidak memfilter event di SQL?
+//   - Kolom events berisi daftar dipisah koma; jumlah subscription kecil sehingga filter di Go lebih sederhana
+//     daripada FIND_IN_SET yang tidak bisa memakai index
+func (r *WebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.ListActiveSubscriptions")
+	defer span.End()
+
+	return r.querySubscriptions(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE active = TRUE ORDER BY id`)
+}
+
+func (r *WebhookRepository) querySubscriptions(ctx context.Context, query string) ([]model.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []model.WebhookSubscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id int) (*model.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.GetSubscription")
+	defer span.End()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE id = ?`, id)
+	sub, err := scanSubscription(row.Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return &sub, err
+}
+
+// DeactivateSubscription menonaktifkan subscription dan memindahkan pengiriman yang masih pending ke dead-letter.
+// Mengembalikan false jika subscription tidak ada atau sudah nonaktif.
+//
+// MENGAPA dinonaktifkan, bukan DELETE?
+//   - Delivery log tetap tersedia untuk audit setelah integrator berhenti berlangganan
+func (r *WebhookRepository) DeactivateSubscription(ctx context.Context, id int) (bool, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.DeactivateSubscription")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE webhook_subscriptions SET active = FALSE WHERE id = ? AND active = TRUE`, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+          UPDATE webhook_deliveries
+          SET status = ?, claim_token = NULL, last_error = 'subscription deactivated'
+          WHERE subscription_id = ? AND status = ?
+       `, DeliveryDead, id, DeliveryPending); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// CreateDeliveries memasukkan banyak pengiriman dalam satu INSERT multi-row.
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.CreateDeliveries")
+	defer span.End()
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(deliveries))
+	args := make([]any, 0, len(deliveries)*7)
+	for i, d := range deliveries {
+		values[i] = "(?, ?, ?, ?, ?, ?, ?)"
+		args = append(args, d.SubscriptionID, d.EventID, d.EventType, d.Aggregate, string(d.Payload), DeliveryPending, d.NextAttemptAt.Truncate(time.Second))
+	}
+
+	query := `INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, aggregate, payload, status, next_attempt_at) VALUES ` +
+		strings.Join(values, ", ")
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// ClaimDue mengklaim paling banyak limit pengiriman yang jatuh tempo untuk dikirim worker ini.
+//
+// MENGAPA klaim lewat UPDATE claim_token, bukan SELECT ... FOR UPDATE selama pengiriman?
+//   - Request HTTP ke integrator bisa lama; menahan row lock & transaksi selama itu memblokir worker lain
+//   - UPDATE bersifat atomik, sehingga beberapa instance API bisa menjalankan worker tanpa mengirim event yang sama
+//   - next_attempt_at digeser ke leaseUntil: jika proses mati di tengah pengiriman, delivery otomatis
+//     bisa diklaim ulang setelah lease habis (at-least-once)
+//
+// Hanya delivery terdepan per (subscription, aggregate) yang diklaim: delivery yang masih ada pendahulunya
+// berstatus pending (menunggu retry atau sedang dikirim) ditahan, sehingga event satu pinjaman sampai berurutan.
+// Kondisi status & next_attempt_at diulang di luar derived table karena derived table dibaca tanpa lock;
+// UPDATE memeriksanya lagi pada versi row terbaru, sehingga dua worker tidak mengklaim delivery yang sama.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, token string, now, leaseUntil time.Time, limit int) ([]model.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.ClaimDue")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, `
+          UPDATE webhook_deliveries d
+          JOIN (
+              SELECT w.id
+              FROM webhook_deliveries w
+              WHERE w.status = ? AND w.next_attempt_at <= ?
+                AND NOT EXISTS (
+                    SELECT 1
+                    FROM webhook_deliveries p
+                    WHERE p.subscription_id = w.subscription_id
+                      AND p.aggregate = w.aggregate
+                      AND p.status = ?
+                      AND p.id < w.id
+                )
+              ORDER BY w.next_attempt_at, w.id
+              LIMIT ?
+          ) heads ON d.id = heads.id
+          SET d.claim_token = ?, d.next_attempt_at = ?
+          WHERE d.status = ? AND d.next_attempt_at <= ?
+       `, DeliveryPending, now, DeliveryPending, limit, token, leaseUntil.Truncate(time.Second), DeliveryPending, now)
+	if err != nil {
+		return nil, err
+	}
+	if claimed, err := result.RowsAffected(); err != nil || claimed == 0 {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+          SELECT d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.attempts, s.url, s.secret
+          FROM webhook_deliveries d
+          JOIN webhook_subscriptions s ON d.subscription_id = s.id
+          WHERE d.claim_token = ? AND d.status = ?
+          ORDER BY d.id
+       `, token, DeliveryPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		var d model.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Attempts, &d.URL, &d.Secret); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// MarkDelivered mencatat pengiriman yang berhasil. Kondisi claim_token mencegah worker yang lease-nya
+// sudah habis menimpa hasil worker lain yang mengklaim ulang delivery yang sama.
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64, token string, statusCode int, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.MarkDelivered")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, `
+          UPDATE webhook_deliveries
+          SET status = ?, attempts = attempts + 1, claim_token = NULL,
+              last_status_code = ?, last_error = NULL, delivered_at = ?
+          WHERE id = ? AND claim_token = ?
+       `, DeliveryDelivered, statusCode, now.Truncate(time.Second), id, token)
+	return err
+}
+
+// MarkFailed mencatat percobaan yang gagal: dijadwalkan ulang pada nextAttemptAt, atau masuk dead-letter jika dead.
+// statusCode nil berarti request tidak mendapat response (timeout, koneksi ditolak).
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id int64, token string, statusCode *int, lastError string, nextAttemptAt time.Time, dead bool) error {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.MarkFailed")
+	defer span.End()
+
+	status := DeliveryPending
+	if dead {
+		status = DeliveryDead
+	}
+	if len(lastError) > 512 {
+		lastError = lastError[:512]
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+          UPDATE webhook_deliveries
+          SET status = ?, attempts = attempts + 1, claim_token = NULL,
+              last_status_code = ?, last_error = ?, next_attempt_at = ?
+          WHERE id = ? AND claim_token = ?
+       `, status, statusCode, lastError, nextAttemptAt.Truncate(time.Second), id, token)
+	return err
+}
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, status, attempts, next_attempt_at,
+              last_status_code, last_error, created_at, delivered_at`
+
+// ListDeliveries mengambil delivery log satu subscription, terbaru di atas.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID, limit int) ([]model.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.ListDeliveries")
+	defer span.End()
+
+	return r.queryDeliveries(ctx, `
+          SELECT `+deliveryColumns+`
+          FROM webhook_deliveries
+          WHERE subscription_id = ?
+          ORDER BY id DESC
+          LIMIT ?
+       `, subscriptionID, limit)
+}
+
+// ListDeadLetters mengambil pengiriman yang berhenti dicoba (percobaan habis atau subscription dinonaktifkan).
+func (r *WebhookRepository) ListDeadLetters(ctx context.Context, limit int) ([]model.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.ListDeadLetters")
+	defer span.End()
+
+	return r.queryDeliveries(ctx, `
+          SELECT `+deliveryColumns+`
+          FROM webhook_deliveries
+          WHERE status = ?
+          ORDER BY id DESC
+          LIMIT ?
+       `, DeliveryDead, limit)
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int64) (*model.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.GetDelivery")
+	defer span.End()
+
+	deliveries, err := r.queryDeliveries(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = ?`, id)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+func (r *WebhookRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]model.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		var d model.WebhookDelivery
+		if err := rows.Scan(
+			&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// Requeue mengembalikan pengiriman dead-letter ke antrean dengan jumlah percobaan baru.
+// Hanya berhasil jika subscription-nya masih aktif; mengembalikan false jika tidak ada yang di-requeue.
+// Delivery yang di-requeue kembali menahan delivery pending berikutnya dari aggregate yang sama (lihat ClaimDue),
+// tetapi delivery yang sudah terkirim selama ia dead tidak diulang: integrator menerimanya di luar urutan.
+func (r *WebhookRepository) Requeue(ctx context.Context, id int64, now time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.Requeue")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, `
+          UPDATE webhook_deliveries
+          SET status = ?, attempts = 0, next_attempt_at = ?, claim_token = NULL
+          WHERE id = ? AND status = ?
+            AND subscription_id IN (SELECT id FROM webhook_subscriptions WHERE active = TRUE)
+       `, DeliveryPending, now.Truncate(time.Second), id, DeliveryDead)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
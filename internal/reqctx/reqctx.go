@@ -0,0 +1,79 @@
+// Package reqctx menyimpan metadata per-request (trace ID, member ID) di context.Context
+// sehingga handler, service, logger, dan error mapper membaca nilai yang sama.
+package reqctx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+type contextKey struct{}
+
+// Info adalah metadata satu request (atau satu job background).
+// TraceID berasal dari header X-Request-ID jika dikirim client, atau dibuat oleh middleware RequestID.
+// MENGAPA pointer yang bisa diubah, bukan nilai immutable di context?
+//   - Member ID baru diketahui setelah handler mem-parse body/path, sedangkan access log
+//     (yang membuat context) membacanya setelah handler selesai
+//   - context.WithValue di handler tidak terlihat oleh middleware di luarnya
+type Info struct {
+	TraceID  string
+	MemberID int
+	// Route adalah path template yang cocok (misalnya /api/v1/books/{id}), diisi middleware MatchRoute.
+	Route string
+	// MemberLanguage adalah preferensi bahasa member yang dilayani (kosong jika tidak diset),
+	// dipakai i18n.ForRequest jika client tidak mengirim Accept-Language.
+	MemberLanguage string
+}
+
+// With menyimpan info ke context.
+func With(ctx context.Context, info *Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// From mengambil info dari context, nil jika context tidak berasal dari request HTTP.
+func From(ctx context.Context) *Info {
+	info, _ := ctx.Value(contextKey{}).(*Info)
+	return info
+}
+
+// TraceID mengembalikan trace ID request, atau string kosong jika tidak ada.
+func TraceID(ctx context.Context) string {
+	if info := From(ctx); info != nil {
+		return info.TraceID
+	}
+	return ""
+}
+
+// SetMemberID mencatat member yang sedang dilayani request, untuk keperluan access log.
+func SetMemberID(ctx context.Context, memberID int) {
+	if info := From(ctx); info != nil {
+		info.MemberID = memberID
+	}
+}
+
+// SetMemberLanguage mencatat preferensi bahasa member, dipanggil service setelah data member dibaca.
+func SetMemberLanguage(ctx context.Context, language string) {
+	if info := From(ctx); info != nil {
+		info.MemberLanguage = language
+	}
+}
+
+// WithNewTraceID menyimpan Info dengan trace ID baru ke context.
+// Digunakan oleh pekerjaan di luar request HTTP (CLI, job background) agar log & query DB-nya tetap berkorelasi.
+func WithNewTraceID(ctx context.Context) context.Context {
+	return With(ctx, &Info{TraceID: GenerateTraceID()})
+}
+
+// GenerateTraceID generates random string untuk tracking request
+// MENGAPA menggunakan crypto/rand?
+// - Lebih secure dan random dibanding math/rand
+// - Menghindari collision antar trace_id
+func GenerateTraceID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405") + "-fallback"
+	}
+	return hex.EncodeToString(b)
+}
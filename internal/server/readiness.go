@@ -0,0 +1,23 @@
+package server
+
+import "sync/atomic"
+
+// Readiness menandai apakah instance siap menerima traffic.
+// MENGAPA terpisah dari Server?
+// - Handler health check perlu membaca status ini, padahal router dibuat sebelum Server
+// - atomic.Bool aman dibaca dari banyak goroutine request tanpa mutex
+type Readiness struct {
+	ready atomic.Bool
+}
+
+func NewReadiness() *Readiness {
+	return &Readiness{}
+}
+
+func (r *Readiness) IsReady() bool {
+	return r.ready.Load()
+}
+
+func (r *Readiness) SetReady(ready bool) {
+	r.ready.Store(ready)
+}
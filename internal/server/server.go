@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+)
+
+// Server membungkus http.Server (dan gRPC server jika diaktifkan) dengan lifecycle: start lalu drain saat shutdown.
+// Pool DB tidak ditutup di sini karena worker di main (relay outbox, dispatcher webhook) masih memakainya
+// sampai mereka selesai; main menutupnya setelah server dan semua worker berhenti.
+type Server struct {
+	httpServer      *http.Server
+	grpc            *grpcServer
+	readiness       *Readiness
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+}
+
+func New(cfg *config.Config, handler http.Handler, readiness *Readiness) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              ":" + cfg.ServerPort,
+			Handler:           handler,
+			ReadTimeout:       cfg.ServerReadTimeout,
+			ReadHeaderTimeout: cfg.ServerReadHeaderTimeout,
+			WriteTimeout:      cfg.ServerWriteTimeout,
+			IdleTimeout:       cfg.ServerIdleTimeout,
+		},
+		readiness:       readiness,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		drainDelay:      cfg.ShutdownDrainDelay,
+	}
+}
+
+// grpcServer adalah gRPC API yang berbagi lifecycle (ready, drain) dengan HTTP server.
+type grpcServer struct {
+	server *grpc.Server
+	health *health.Server
+	addr   string
+}
+
+// WithGRPC menjalankan gRPC server di port terpisah bersama HTTP server.
+// Health server mengikuti readiness: NOT_SERVING selama drain, seperti /readyz yang menjawab 503.
+func (s *Server) WithGRPC(server *grpc.Server, healthServer *health.Server, port string) *Server {
+	s.grpc = &grpcServer{server: server, health: healthServer, addr: ":" + port}
+	return s
+}
+
+// OnShutdown mendaftarkan f yang dipanggil saat HTTP server mulai shutdown (setelah drain delay), untuk
+// mengakhiri request berumur panjang seperti stream SSE yang tidak akan selesai sendiri.
+func (s *Server) OnShutdown(f func()) {
+	s.httpServer.RegisterOnShutdown(f)
+}
+
+// Run menjalankan server sampai ctx dibatalkan (misalnya oleh SIGTERM), lalu melakukan graceful shutdown:
+//  1. readiness → "not ready" agar load balancer berhenti mengirim request baru
+//  2. tunggu drainDelay, lalu berhenti menerima koneksi baru
+//  3. tunggu request yang sedang berjalan (misalnya transaksi borrow) selesai, maksimal shutdownTimeout
+func (s *Server) Run(ctx context.Context) error {
+	// Alasan memisahkan Listen dari Serve: readiness baru boleh "ready" setelah port benar-benar terbuka.
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+
+	var grpcListener net.Listener
+	if s.grpc != nil {
+		grpcListener, err = net.Listen("tcp", s.grpc.addr)
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
+	}
+
+	// Buffer 2: HTTP dan gRPC masing-masing mengirim paling banyak satu error.
+	serveErr := make(chan error, 2)
+	go func() {
+		serveErr <- s.httpServer.Serve(listener)
+	}()
+	if s.grpc != nil {
+		go func() {
+			serveErr <- s.grpc.server.Serve(grpcListener)
+		}()
+	}
+
+	s.readiness.SetReady(true)
+	slog.Info("server listening", "addr", s.httpServer.Addr)
+	if s.grpc != nil {
+		s.grpc.health.Resume()
+		slog.Info("grpc server listening", "addr", s.grpc.addr)
+	}
+
+	select {
+	case err := <-serveErr:
+		// Salah satu server berhenti sendiri (bukan karena sinyal), hentikan yang lain sebelum keluar.
+		s.readiness.SetReady(false)
+		_ = s.httpServer.Close()
+		if s.grpc != nil {
+			s.grpc.server.Stop()
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutdown signal received, draining in-flight requests",
+		"drain_delay", s.drainDelay.String(), "timeout", s.shutdownTimeout.String())
+	s.readiness.SetReady(false)
+	if s.grpc != nil {
+		s.grpc.health.Shutdown()
+	}
+
+	if s.drainDelay > 0 {
+		time.Sleep(s.drainDelay)
+	}
+
+	// Alasan memakai context.Background sebagai parent: ctx asal sudah dibatalkan oleh sinyal.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	// Shutdown menutup listener lalu menunggu semua koneksi aktif idle.
+	// Context request yang sedang berjalan TIDAK dibatalkan, sehingga transaksi DB bisa commit dengan normal.
+	// gRPC di-drain bersamaan dengan HTTP: GracefulStop menunggu RPC yang berjalan, Stop memutusnya jika timeout.
+	grpcStopped := make(chan struct{})
+	if s.grpc != nil {
+		go func() {
+			defer close(grpcStopped)
+			done := make(chan struct{})
+			go func() {
+				s.grpc.server.GracefulStop()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-shutdownCtx.Done():
+				slog.Warn("grpc graceful stop timed out, forcing close")
+				s.grpc.server.Stop()
+			}
+		}()
+	} else {
+		close(grpcStopped)
+	}
+
+	shutdownErr := s.httpServer.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		slog.Warn("graceful shutdown timed out, forcing close", "error", shutdownErr)
+		_ = s.httpServer.Close()
+	}
+	<-grpcStopped
+
+	servers := 1
+	if s.grpc != nil {
+		servers = 2
+	}
+	for i := 0; i < servers; i++ {
+		// Serve gRPC mengembalikan nil setelah GracefulStop/Stop; HTTP mengembalikan ErrServerClosed.
+		if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Warn("server error during shutdown", "error", err)
+		}
+	}
+
+	slog.Info("server stopped")
+
+	return shutdownErr
+}
@@ -2,18 +2,24 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/Ar1veeee/library-api/internal/dto"
 	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/model"
 	"github.com/Ar1veeee/library-api/internal/repository"
 )
 
 type BookService struct {
+	db       *sql.DB
 	bookRepo *repository.BookRepository
 }
 
-func NewBookService(bookRepo *repository.BookRepository) *BookService {
-	return &BookService{bookRepo: bookRepo}
+func NewBookService(db *sql.DB, bookRepo *repository.BookRepository) *BookService {
+	return &BookService{db: db, bookRepo: bookRepo}
 }
 
 func (s *BookService) GetAllBooks(ctx context.Context) (*dto.BooksListResponse, error) {
@@ -38,13 +44,33 @@ func (s *BookService) GetAllBooks(ctx context.Context) (*dto.BooksListResponse,
 	}, nil
 }
 
+// GetBooksByIDs mengambil banyak buku dalam satu query, untuk data loader GraphQL.
+// ID yang tidak ditemukan tidak ada di map; pemanggil yang memutuskan apakah itu error.
+func (s *BookService) GetBooksByIDs(ctx context.Context, bookIDs []int) (map[int]dto.BookResponse, error) {
+	books, err := s.bookRepo.GetByIDs(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]dto.BookResponse, len(books))
+	for _, book := range books {
+		result[book.ID] = dto.BookResponse{
+			ID:     book.ID,
+			Title:  book.Title,
+			Author: book.Author,
+			Stock:  book.Stock,
+		}
+	}
+	return result, nil
+}
+
 func (s *BookService) GetBookByID(ctx context.Context, bookID int) (*dto.BookResponse, error) {
 	book, err := s.bookRepo.GetByID(ctx, bookID)
 	if err != nil {
 		return nil, err
 	}
 	if book == nil {
-		return nil, errors.NewAPIError("Buku tidak ditemukan", errors.ErrCodeNotFound)
+		return nil, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgBookNotFound)
 	}
 
 	bookData := &dto.BookResponse{
@@ -56,3 +82,176 @@ func (s *BookService) GetBookByID(ctx context.Context, bookID int) (*dto.BookRes
 
 	return bookData, nil
 }
+
+// ExportCatalog mengembalikan seluruh katalog termasuk jumlah eksemplar untuk keperluan backup/export.
+func (s *BookService) ExportCatalog(ctx context.Context) ([]dto.CatalogEntry, error) {
+	books, err := s.bookRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]dto.CatalogEntry, len(books))
+	for i, book := range books {
+		entries[i] = dto.CatalogEntry{
+			ID:          book.ID,
+			Title:       book.Title,
+			Author:      book.Author,
+			TotalCopies: book.TotalCopies,
+			Stock:       book.Stock,
+		}
+	}
+
+	return entries, nil
+}
+
+// ImportCatalog menambah atau memperbarui buku dari daftar entry dalam satu transaksi.
+// Entry dengan ID memperbarui buku yang ada atau ditambahkan dengan ID tersebut jika belum ada (upsert),
+// entry tanpa ID ditambahkan sebagai buku baru.
+// Kolom Stock pada entry diabaikan: stok selalu diturunkan dari total_copies dikurangi pinjaman aktif.
+func (s *BookService) ImportCatalog(ctx context.Context, entries []dto.CatalogEntry) (*dto.ImportCatalogResult, error) {
+	// Validasi seluruh entry sebelum membuka transaksi.
+	// Alasan: file import yang rusak di baris ke-N tidak perlu menahan lock untuk N-1 baris sebelumnya.
+	for i, entry := range entries {
+		if strings.TrimSpace(entry.Title) == "" || strings.TrimSpace(entry.Author) == "" {
+			return nil, errors.NewAPIError(
+				fmt.Sprintf("Baris %d: title dan author wajib diisi", i+1),
+				errors.ErrCodeInvalidInput,
+			)
+		}
+		if entry.TotalCopies < 0 {
+			return nil, errors.NewAPIError(
+				fmt.Sprintf("Baris %d: total_copies tidak boleh negatif", i+1),
+				errors.ErrCodeInvalidInput,
+			)
+		}
+	}
+
+	// Alasan satu transaksi untuk seluruh import: katalog tidak boleh setengah ter-import jika ada baris yang gagal.
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, errors.NewLocalizedError(errors.ErrCodeTxFailed, i18n.MsgTxBeginFailed).WithCause(err)
+	}
+	defer tx.Rollback()
+
+	result := &dto.ImportCatalogResult{}
+	for i, entry := range entries {
+		book := &model.Book{
+			ID:          entry.ID,
+			Title:       strings.TrimSpace(entry.Title),
+			Author:      strings.TrimSpace(entry.Author),
+			TotalCopies: entry.TotalCopies,
+			Stock:       entry.TotalCopies,
+		}
+
+		if book.ID == 0 {
+			if _, err := s.bookRepo.Create(ctx, tx, book); err != nil {
+				return nil, errors.NewAPIError(
+					fmt.Sprintf("Baris %d: gagal menambah buku", i+1),
+					errors.ErrCodeTxFailed,
+				).WithCause(err)
+			}
+			result.Created++
+			continue
+		}
+
+		existing, err := s.bookRepo.GetByIDForUpdate(ctx, tx, book.ID)
+		if err != nil {
+			return nil, errors.NewAPIError(
+				fmt.Sprintf("Baris %d: gagal memeriksa buku", i+1),
+				errors.ErrCodeTxFailed,
+			).WithCause(err)
+		}
+		if existing == nil {
+			// Buku belum ada (misalnya export dari instance lain di-import ke database baru): ditambahkan dengan
+			// ID dari file agar referensi ke ID tersebut tetap berlaku. Belum ada pinjaman untuk buku yang
+			// belum ada, sehingga stok sama dengan total_copies.
+			if err := s.bookRepo.CreateWithID(ctx, tx, book); err != nil {
+				return nil, errors.NewAPIError(
+					fmt.Sprintf("Baris %d: gagal menambah buku dengan ID %d", i+1, book.ID),
+					errors.ErrCodeTxFailed,
+				).WithCause(err)
+			}
+			result.Created++
+			continue
+		}
+
+		if err := s.bookRepo.UpdateCatalog(ctx, tx, book); err != nil {
+			return nil, errors.NewAPIError(
+				fmt.Sprintf("Baris %d: gagal memperbarui buku", i+1),
+				errors.ErrCodeTxFailed,
+			).WithCause(err)
+		}
+
+		// Jumlah eksemplar berubah, sehingga stok tersedia ikut dihitung ulang.
+		if _, err := s.recalculateStock(ctx, tx, book.ID); err != nil {
+			return nil, err
+		}
+		result.Updated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Internal("menyimpan transaksi", err)
+	}
+
+	return result, nil
+}
+
+// RecalculateStock menghitung ulang stok semua buku dari total_copies dikurangi pinjaman aktif
+// dan mengembalikan daftar buku yang stoknya dikoreksi.
+func (s *BookService) RecalculateStock(ctx context.Context) ([]dto.StockCorrection, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, errors.NewLocalizedError(errors.ErrCodeTxFailed, i18n.MsgTxBeginFailed).WithCause(err)
+	}
+	defer tx.Rollback()
+
+	corrections, err := s.recalculateStock(ctx, tx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Internal("menyimpan transaksi", err)
+	}
+
+	return corrections, nil
+}
+
+// recalculateStock mengoreksi stok di dalam transaksi yang sudah berjalan (bookID 0 = semua buku).
+func (s *BookService) recalculateStock(ctx context.Context, tx *sql.Tx, bookID int) ([]dto.StockCorrection, error) {
+	ledger, err := s.bookRepo.GetStockLedgerForUpdate(ctx, tx, bookID)
+	if err != nil {
+		return nil, errors.Internal("membaca stok buku", err)
+	}
+
+	var corrections []dto.StockCorrection
+	for _, entry := range ledger {
+		// Stok tidak boleh negatif walaupun pinjaman aktif melebihi eksemplar
+		// (misalnya total_copies dikurangi saat sebagian masih dipinjam).
+		expected := entry.TotalCopies - entry.ActiveLoans
+		if expected < 0 {
+			expected = 0
+		}
+		if expected == entry.Stock {
+			continue
+		}
+
+		if err := s.bookRepo.SetStock(ctx, tx, entry.BookID, expected); err != nil {
+			return nil, errors.NewAPIError(
+				fmt.Sprintf("Gagal mengoreksi stok buku %d", entry.BookID),
+				errors.ErrCodeTxFailed,
+			).WithCause(err)
+		}
+
+		corrections = append(corrections, dto.StockCorrection{
+			BookID:      entry.BookID,
+			Title:       entry.Title,
+			TotalCopies: entry.TotalCopies,
+			ActiveLoans: entry.ActiveLoans,
+			OldStock:    entry.Stock,
+			NewStock:    expected,
+		})
+	}
+
+	return corrections, nil
+}
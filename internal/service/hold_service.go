@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/model"
+	"github.com/Ar1veeee/library-api/internal/repository"
+)
+
+// HoldService membaca reservasi (antrean) buku untuk dashboard member di GraphQL.
+// Membuat dan membatalkan reservasi belum tersedia: peminjaman tidak memperhitungkan antrean.
+type HoldService struct {
+	holdRepo *repository.HoldRepository
+	location *time.Location
+}
+
+func NewHoldService(holdRepo *repository.HoldRepository, location *time.Location) *HoldService {
+	return &HoldService{holdRepo: holdRepo, location: location}
+}
+
+// GetHoldsByMemberIDs mengambil reservasi banyak member dalam satu query, untuk data loader GraphQL.
+func (s *HoldService) GetHoldsByMemberIDs(ctx context.Context, memberIDs []int) (map[int][]dto.HoldDetail, error) {
+	holds, err := s.holdRepo.GetByMemberIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int][]dto.HoldDetail, len(memberIDs))
+	for _, hold := range holds {
+		result[hold.MemberID] = append(result[hold.MemberID], s.holdDetail(hold))
+	}
+	return result, nil
+}
+
+// holdDetail mengisi posisi antrean dari Ahead; hanya berlaku untuk reservasi waiting.
+func (s *HoldService) holdDetail(hold model.Hold) dto.HoldDetail {
+	detail := dto.HoldDetail{
+		HoldID:    hold.ID,
+		MemberID:  hold.MemberID,
+		BookID:    hold.BookID,
+		Status:    hold.Status,
+		CreatedAt: formatTimestamp(hold.CreatedAt, s.location),
+	}
+	if hold.ClosedAt != nil {
+		closedAt := formatTimestamp(*hold.ClosedAt, s.location)
+		detail.ClosedAt = &closedAt
+	}
+	if hold.Status == model.HoldWaiting {
+		position := hold.Ahead + 1
+		detail.Position = &position
+	}
+	return detail
+}
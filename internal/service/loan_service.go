@@ -3,20 +3,44 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
+	"github.com/Ar1veeee/library-api/internal/clock"
 	"github.com/Ar1veeee/library-api/internal/dto"
 	errorStruct "github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/event"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/metrics"
+	"github.com/Ar1veeee/library-api/internal/model"
 	"github.com/Ar1veeee/library-api/internal/repository"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+	"github.com/Ar1veeee/library-api/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// LoanPolicy adalah aturan peminjaman yang bisa diatur lewat konfigurasi (loan.max_active, loan.period_days).
+type LoanPolicy struct {
+	// MaxActiveLoans adalah batas buku yang boleh dipinjam bersamaan oleh satu member.
+	MaxActiveLoans int
+	// LoanPeriodDays adalah masa pinjam sebelum pinjaman dianggap terlambat (overdue).
+	LoanPeriodDays int
+}
+
 type LoanService struct {
 	db         *sql.DB
 	bookRepo   *repository.BookRepository
 	memberRepo *repository.MemberRepository
 	loanRepo   *repository.LoanRepository
+	fineRepo   *repository.FineRepository
+	policy     LoanPolicy
+	location   *time.Location
+	clock      clock.Clock
+	outboxRepo *repository.OutboxRepository
+	// stockChanged membangunkan stream stok di proses ini setelah commit (boleh nil, misalnya di libctl).
+	stockChanged func()
 }
 
 func NewLoanService(
@@ -24,16 +48,39 @@ func NewLoanService(
 	bookRepo *repository.BookRepository,
 	memberRepo *repository.MemberRepository,
 	loanRepo *repository.LoanRepository,
+	fineRepo *repository.FineRepository,
+	policy LoanPolicy,
+	location *time.Location,
+	clock clock.Clock,
+	outboxRepo *repository.OutboxRepository,
+	stockChanged func(),
 ) *LoanService {
 	return &LoanService{
-		db:         db,
-		bookRepo:   bookRepo,
-		memberRepo: memberRepo,
-		loanRepo:   loanRepo,
+		db:           db,
+		bookRepo:     bookRepo,
+		memberRepo:   memberRepo,
+		loanRepo:     loanRepo,
+		fineRepo:     fineRepo,
+		policy:       policy,
+		location:     location,
+		clock:        clock,
+		outboxRepo:   outboxRepo,
+		stockChanged: stockChanged,
 	}
 }
 
-func (s *LoanService) BorrowBook(ctx context.Context, memberID, bookID int) (*dto.LoanDetail, error) {
+func (s *LoanService) BorrowBook(ctx context.Context, memberID, bookID int) (_ *dto.LoanDetail, err error) {
+	ctx, span := tracer.Start(ctx, "LoanService.BorrowBook", trace.WithAttributes(
+		attribute.Int("member.id", memberID),
+		attribute.Int("book.id", bookID),
+	))
+
+	// Hasil akhir (sukses / ditolak dengan kode ZYD-ERR tertentu) dicatat ke metric dan span lewat named return err.
+	defer func() {
+		metrics.ObserveLoanOperation("borrow", err)
+		tracing.End(span, err)
+	}()
+
 	// Alasan memilih sql.LevelReadCommitted:
 	// - Mencegah dirty read (melihat data yang belum di-commit).
 	// - Masih mengizinkan non-repeatable read, yang aman untuk use case ini karena kita menggunakan row-level locking (FOR UPDATE) pada query kritis.
@@ -41,10 +88,7 @@ func (s *LoanService) BorrowBook(ctx context.Context, memberID, bookID int) (*dt
 	// - Untuk sistem perpustakaan sederhana, isolation ini memberikan balance terbaik antara konsistensi dan performa.
 	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
-		return nil, errorStruct.NewAPIError(
-			"Gagal memulai transaksi database",
-			errorStruct.ErrCodeTxFailed,
-		)
+		return nil, errorStruct.NewLocalizedError(errorStruct.ErrCodeTxFailed, i18n.MsgTxBeginFailed).WithCause(err)
 	}
 
 	// defer tx.Rollback() diletakkan segera setelah BeginTx berhasil.
@@ -55,33 +99,24 @@ func (s *LoanService) BorrowBook(ctx context.Context, memberID, bookID int) (*dt
 	// Validasi check apakah member ada
 	member, err := s.memberRepo.GetByID(ctx, memberID)
 	if err != nil {
-		return nil, errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal memeriksa member :%v", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+		return nil, errorStruct.Internal("memeriksa member", err)
 	}
 	if member == nil {
-		return nil, errorStruct.NewAPIError(
-			"Member tidak ditemukan",
-			errorStruct.ErrCodeNotFound,
-		)
+		return nil, errorStruct.NewLocalizedError(errorStruct.ErrCodeNotFound, i18n.MsgMemberNotFound)
 	}
+	reqctx.SetMemberLanguage(ctx, member.Language)
 
 	// CountActiveLoansByMember menggunakan FOR UPDATE: lock semua row loan aktif member.
 	// Alasan: mencegah race condition pada kuota (2 request borrow bersamaan bisa bypass batas 3).
 	// Lock ini membuat transaksi kedua menunggu hingga yang pertama commit.
 	activeLoans, err := s.loanRepo.CountActiveLoansByMember(ctx, tx, memberID)
 	if err != nil {
-		return nil, errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal memeriksa kuota member %v:", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+		return nil, errorStruct.Internal("memeriksa kuota member", err)
 	}
-	if activeLoans >= 3 {
-		return nil, errorStruct.NewAPIError(
-			"Member sudah mencapai batas pinjam maksimal yaitu 3 buku",
-			errorStruct.ErrCodeQuotaExceeded,
-		)
+	if activeLoans >= s.policy.MaxActiveLoans {
+		return nil, errorStruct.NewLocalizedError(errorStruct.ErrCodeQuotaExceeded, i18n.MsgLoanQuotaExceeded, s.policy.MaxActiveLoans).
+			WithMetadata("max_active_loans", s.policy.MaxActiveLoans).
+			WithMetadata("active_loans", activeLoans)
 	}
 
 	// GetByIDForUpdate dengan FOR UPDATE → lock row buku.
@@ -89,37 +124,22 @@ func (s *LoanService) BorrowBook(ctx context.Context, memberID, bookID int) (*dt
 	// Kombinasi dengan atomic decrement membuat operasi stok benar-benar aman.
 	book, err := s.bookRepo.GetByIDForUpdate(ctx, tx, bookID)
 	if err != nil {
-		return nil, errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal memeriksa buku %v:", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+		return nil, errorStruct.Internal("memeriksa buku", err)
 	}
 	if book == nil {
-		return nil, errorStruct.NewAPIError(
-			"Buku tidak ditemukan",
-			errorStruct.ErrCodeNotFound,
-		)
+		return nil, errorStruct.NewLocalizedError(errorStruct.ErrCodeNotFound, i18n.MsgBookNotFound)
 	}
 	if book.Stock <= 0 {
-		return nil, errorStruct.NewAPIError(
-			"Stock buku habis",
-			errorStruct.ErrCodeStockEmpty,
-		)
+		return nil, errorStruct.NewLocalizedError(errorStruct.ErrCodeStockEmpty, i18n.MsgBookOutOfStock)
 	}
 
 	// Validasi Check apakah member sudah pinjam buku yang sama
 	exists, err := s.loanRepo.CheckActiveLoanExists(ctx, tx, memberID, bookID)
 	if err != nil {
-		return nil, errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal memeriksa status peminjaman %v:", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+		return nil, errorStruct.Internal("memeriksa status peminjaman", err)
 	}
 	if exists {
-		return nil, errorStruct.NewAPIError(
-			"Anda sedang meminjam buku ini",
-			errorStruct.ErrCodeAlreadyBorrowed,
-		)
+		return nil, errorStruct.NewLocalizedError(errorStruct.ErrCodeAlreadyBorrowed, i18n.MsgLoanAlreadyBorrowed)
 	}
 
 	// DecrementStock menggunakan atomic UPDATE dengan kondisi stock > 0.
@@ -127,31 +147,14 @@ func (s *LoanService) BorrowBook(ctx context.Context, memberID, bookID int) (*dt
 	// (mencegah race condition jika ada bug atau perubahan logika di masa depan).
 	if err := s.bookRepo.DecrementStock(ctx, tx, bookID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errorStruct.NewAPIError(
-				"Stok buku habis",
-				errorStruct.ErrCodeStockEmpty,
-			)
+			return nil, errorStruct.NewLocalizedError(errorStruct.ErrCodeStockEmpty, i18n.MsgBookOutOfStock)
 		}
-		return nil, errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal mengurangi stok %v:", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+		return nil, errorStruct.Internal("mengurangi stok", err)
 	}
 
-	loanID, err := s.loanRepo.Create(ctx, tx, memberID, bookID)
+	loan, err := s.loanRepo.Create(ctx, tx, memberID, bookID, s.clock.Now())
 	if err != nil {
-		return nil, errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal mencatat peminjaman: %v", err),
-			errorStruct.ErrCodeTxFailed,
-		)
-	}
-
-	// COMMIT TRANSACTION
-	if err := tx.Commit(); err != nil {
-		return nil, errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal menyimpan transaksi: %v", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+		return nil, errorStruct.Internal("mencatat peminjaman", err)
 	}
 
 	// Alasan mengembalikan detail loan:
@@ -159,35 +162,53 @@ func (s *LoanService) BorrowBook(ctx context.Context, memberID, bookID int) (*dt
 	// - Menampilkan detail buku dan timestamp akurat tanpa perlu query ulang.
 	// - Memberikan feedback yang lebih kaya di response API.
 
-	// Alasan menggunakan time.Now().In(time.FixedZone("WIB", 7*3600)):
-	// - Timestamp di repository menggunakan NOW() (waktu server DB, biasanya UTC).
-	// - Untuk response ke client (Indonesia), lebih user-friendly menampilkan waktu lokal WIB (UTC+7).
-	// - FixedZone digunakan karena timezone Indonesia tidak ada DST.
-	now := time.Now().In(time.FixedZone("WIB", 7*3600))
-	borrowedAtFormatted := now.Format("2006-01-02 15:04:05")
-
 	loanDetail := &dto.LoanDetail{
-		LoanID:     int(loanID),
+		LoanID:     loan.ID,
 		MemberID:   memberID,
 		BookID:     bookID,
 		BookTitle:  book.Title,
 		BookAuthor: book.Author,
-		BorrowedAt: borrowedAtFormatted,
+		BorrowedAt: formatTimestamp(loan.BorrowedAt, s.location),
+	}
+
+	// Stok yang dibaca dengan FOR UPDATE adalah stok sebelum decrement, sehingga 1 berarti salinan terakhir baru dipinjam.
+	events := []event.Event{event.New(event.LoanCreated, event.LoanAggregate(loan.ID), loan.BorrowedAt.In(s.location), loanDetail)}
+	if book.Stock == 1 {
+		events = append(events, event.New(event.BookOutOfStock, event.BookAggregate(book.ID), loan.BorrowedAt.In(s.location), dto.BookOutOfStockEvent{
+			BookID: book.ID,
+			Title:  book.Title,
+			Author: book.Author,
+		}))
+	}
+	if err := s.recordEvents(ctx, tx, events...); err != nil {
+		return nil, err
+	}
+
+	// COMMIT TRANSACTION
+	if err := tx.Commit(); err != nil {
+		return nil, errorStruct.Internal("menyimpan transaksi", err)
 	}
+	s.notifyStockChanged()
 
 	return loanDetail, nil
 }
 
-func (s *LoanService) ReturnBook(ctx context.Context, memberID, bookID int) error {
+func (s *LoanService) ReturnBook(ctx context.Context, memberID, bookID int) (err error) {
+	ctx, span := tracer.Start(ctx, "LoanService.ReturnBook", trace.WithAttributes(
+		attribute.Int("member.id", memberID),
+		attribute.Int("book.id", bookID),
+	))
+	defer func() {
+		metrics.ObserveLoanOperation("return", err)
+		tracing.End(span, err)
+	}()
+
 	// Isolation level sama dengan BorrowBook untuk konsistensi behavior transaksi.
 	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
 		Isolation: sql.LevelReadCommitted,
 	})
 	if err != nil {
-		return errorStruct.NewAPIError(
-			"Gagal memulai transaksi database",
-			errorStruct.ErrCodeTxFailed,
-		)
+		return errorStruct.NewLocalizedError(errorStruct.ErrCodeTxFailed, i18n.MsgTxBeginFailed).WithCause(err)
 	}
 
 	defer tx.Rollback()
@@ -197,42 +218,273 @@ func (s *LoanService) ReturnBook(ctx context.Context, memberID, bookID int) erro
 	// Juga berguna jika nanti ada logika tambahan seperti denda atau perpanjangan.
 	loan, err := s.loanRepo.GetActiveLoanByMemberAndBook(ctx, tx, memberID, bookID)
 	if err != nil {
-		return errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal memeriksa peminjaman: %v", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+		return errorStruct.Internal("memeriksa peminjaman", err)
+	}
+	if loan == nil {
+		return errorStruct.NewLocalizedError(errorStruct.ErrCodeNotFound, i18n.MsgLoanNotBorrowed)
+	}
+
+	if err := s.completeReturn(ctx, tx, loan); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errorStruct.Internal("menyimpan transaksi", err)
+	}
+	s.notifyStockChanged()
+
+	return nil
+}
+
+// ForceReturn mengembalikan pinjaman berdasarkan loan ID tanpa melalui member.
+// Digunakan staf perpustakaan (misalnya buku dikembalikan lewat drop box atau member tidak bisa hadir).
+func (s *LoanService) ForceReturn(ctx context.Context, loanID int) (err error) {
+	ctx, span := tracer.Start(ctx, "LoanService.ForceReturn", trace.WithAttributes(
+		attribute.Int("loan.id", loanID),
+	))
+	defer func() {
+		metrics.ObserveLoanOperation("force_return", err)
+		tracing.End(span, err)
+	}()
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+	if err != nil {
+		return errorStruct.NewLocalizedError(errorStruct.ErrCodeTxFailed, i18n.MsgTxBeginFailed).WithCause(err)
+	}
+
+	defer tx.Rollback()
+
+	loan, err := s.loanRepo.GetByIDForUpdate(ctx, tx, loanID)
+	if err != nil {
+		return errorStruct.Internal("memeriksa peminjaman", err)
 	}
 	if loan == nil {
-		return errorStruct.NewAPIError(
-			"Anda tidak sedang meminjam buku ini",
-			errorStruct.ErrCodeNotFound,
-		)
+		return errorStruct.NewLocalizedError(errorStruct.ErrCodeNotFound, i18n.MsgLoanNotFound)
+	}
+	if loan.ReturnedAt != nil {
+		return errorStruct.NewLocalizedError(errorStruct.ErrCodeAlreadyReturned, i18n.MsgLoanAlreadyReturned)
+	}
+
+	if err := s.completeReturn(ctx, tx, loan); err != nil {
+		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return errorStruct.Internal("menyimpan transaksi", err)
+	}
+	s.notifyStockChanged()
+
+	return nil
+}
+
+// completeReturn mencatat pengembalian, mengembalikan stok, dan menulis event loan.returned
+// untuk loan yang sudah di-lock.
+func (s *LoanService) completeReturn(ctx context.Context, tx *sql.Tx, loan *model.Loan) error {
+	returnedAt := s.clock.Now().Truncate(time.Second)
+
 	// MarkAsReturned dan IncrementStock dilakukan dalam satu transaksi.
 	// Alasan: menjaga atomicity — stok hanya bertambah jika pengembalian berhasil tercatat.
-	if err := s.loanRepo.MarkAsReturned(ctx, tx, loan.ID); err != nil {
-		return errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal mencatat pengembalian: %v", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+	if err := s.loanRepo.MarkAsReturned(ctx, tx, loan.ID, returnedAt); err != nil {
+		return errorStruct.Internal("mencatat pengembalian", err)
 	}
 
 	// IncrementStock tanpa kondisi khusus karena yakin stok sebelumnya sudah dikurangi.
 	// Alasan: simplifikasi, dan race condition tidak mungkin karena return hanya bisa sekali per loan.
-	if err := s.bookRepo.IncrementStock(ctx, tx, bookID); err != nil {
-		return errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal menambah stok: %v", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+	if err := s.bookRepo.IncrementStock(ctx, tx, loan.BookID); err != nil {
+		return errorStruct.Internal("menambah stok", err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return errorStruct.NewAPIError(
-			fmt.Sprintf("Gagal menyimpan transaksi: %v", err),
-			errorStruct.ErrCodeTxFailed,
-		)
+	return s.recordEvents(ctx, tx, event.New(event.LoanReturned, event.LoanAggregate(loan.ID), returnedAt.In(s.location), dto.LoanReturnedEvent{
+		LoanID:     loan.ID,
+		MemberID:   loan.MemberID,
+		BookID:     loan.BookID,
+		ReturnedAt: formatTimestamp(returnedAt, s.location),
+	}))
+}
+
+// GetFinesByMemberIDs mengambil denda banyak member dalam satu query, untuk data loader GraphQL.
+// Hanya membaca tabel fines; pencatatan dan pembayaran denda bukan bagian dari service ini.
+func (s *LoanService) GetFinesByMemberIDs(ctx context.Context, memberIDs []int) (map[int][]dto.FineRecord, error) {
+	fines, err := s.fineRepo.GetByMemberIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
 	}
 
+	result := make(map[int][]dto.FineRecord, len(memberIDs))
+	for _, fine := range fines {
+		record := dto.FineRecord{
+			FineID:      fine.ID,
+			LoanID:      fine.LoanID,
+			MemberID:    fine.MemberID,
+			BookID:      fine.BookID,
+			DaysOverdue: fine.DaysOverdue,
+			Amount:      fine.Amount,
+			CreatedAt:   formatTimestamp(fine.CreatedAt, s.location),
+		}
+		if fine.PaidAt != nil {
+			paidAt := formatTimestamp(*fine.PaidAt, s.location)
+			record.PaidAt = &paidAt
+		}
+
+		result[fine.MemberID] = append(result[fine.MemberID], record)
+	}
+	return result, nil
+}
+
+// notifyStockChanged dipanggil setelah commit yang mengubah stok. Instance lain tidak perlu dibangunkan:
+// stream mereka membaca stock_changes secara berkala.
+func (s *LoanService) notifyStockChanged() {
+	if s.stockChanged != nil {
+		s.stockChanged()
+	}
+}
+
+// recordEvents menulis event ke outbox di dalam transaksi yang sama dengan perubahan datanya;
+// relay outbox yang mempublikasikannya setelah commit.
+//
+// MENGAPA tidak publish langsung setelah tx.Commit()?
+//   - Jika proses mati di antara commit dan publish, event hilang walaupun peminjaman tersimpan
+//   - Sebaliknya, event tidak pernah terkirim untuk transaksi yang di-rollback
+//
+// Urutan per aggregate terjaga: event loan/buku yang sama ditulis sambil memegang row lock-nya (FOR UPDATE),
+// sehingga id outbox mengikuti urutan commit.
+func (s *LoanService) recordEvents(ctx context.Context, tx *sql.Tx, events ...event.Event) error {
+	now := s.clock.Now()
+	rows := make([]model.OutboxEvent, len(events))
+	for i, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return errorStruct.Internal("menyiapkan event", err)
+		}
+		rows[i] = model.OutboxEvent{
+			EventID:       e.ID,
+			EventType:     string(e.Type),
+			Aggregate:     e.Aggregate,
+			Payload:       payload,
+			NextAttemptAt: now,
+		}
+	}
+
+	if err := s.outboxRepo.Insert(ctx, tx, rows); err != nil {
+		return errorStruct.Internal("mencatat event", err)
+	}
 	return nil
 }
+
+// GetLoansByMemberIDs mengambil riwayat pinjaman banyak member dalam satu query, untuk data loader GraphQL.
+// Status memakai nilai yang sama dengan REST ("loan"/"returned"); due_at dan overdue dihitung dari LoanPolicy.
+func (s *LoanService) GetLoansByMemberIDs(ctx context.Context, memberIDs []int) (map[int][]dto.LoanRecord, error) {
+	loans, err := s.loanRepo.GetByMemberIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	result := make(map[int][]dto.LoanRecord, len(memberIDs))
+	for _, loan := range loans {
+		dueAt := loan.BorrowedAt.AddDate(0, 0, s.policy.LoanPeriodDays)
+
+		record := dto.LoanRecord{
+			LoanID:     loan.ID,
+			MemberID:   loan.MemberID,
+			BookID:     loan.BookID,
+			BorrowedAt: formatTimestamp(loan.BorrowedAt, s.location),
+			DueAt:      formatTimestamp(dueAt, s.location),
+			Status:     "loan",
+			Overdue:    loan.ReturnedAt == nil && now.After(dueAt),
+		}
+		if loan.ReturnedAt != nil {
+			returnedAt := formatTimestamp(*loan.ReturnedAt, s.location)
+			record.ReturnedAt = &returnedAt
+			record.Status = "returned"
+		}
+
+		result[loan.MemberID] = append(result[loan.MemberID], record)
+	}
+	return result, nil
+}
+
+// ListOverdueLoans mengembalikan pinjaman aktif yang melewati masa pinjam, terlama di atas.
+func (s *LoanService) ListOverdueLoans(ctx context.Context) ([]dto.OverdueLoan, error) {
+	now := s.clock.Now()
+	loans, err := s.loanRepo.GetOverdue(ctx, now.AddDate(0, 0, -s.policy.LoanPeriodDays))
+	if err != nil {
+		return nil, err
+	}
+
+	overdue := make([]dto.OverdueLoan, len(loans))
+	for i, loan := range loans {
+		overdue[i] = s.overdueLoan(loan, now)
+	}
+
+	return overdue, nil
+}
+
+// NotifyOverdueLoans menulis event loan.overdue satu kali untuk setiap pinjaman yang baru melewati masa pinjam,
+// dan mengembalikan jumlah pinjaman yang dinotifikasi. Dijalankan berkala oleh API.
+//
+// MENGAPA ditandai (MarkOverdueNotified) dan ditulis ke outbox dalam satu transaksi per pinjaman?
+//   - Penandaan bersyarat menjadi "klaim": jika beberapa instance memindai bersamaan, hanya satu yang menulis event
+//   - Tanda dan event selalu tersimpan bersama; kegagalan di tengah pemindaian tidak meninggalkan pinjaman
+//     yang sudah ditandai tanpa event
+func (s *LoanService) NotifyOverdueLoans(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	loans, err := s.loanRepo.GetOverdueUnnotified(ctx, now.AddDate(0, 0, -s.policy.LoanPeriodDays))
+	if err != nil {
+		return 0, err
+	}
+
+	notified := 0
+	for _, loan := range loans {
+		claimed, err := s.notifyOverdue(ctx, loan, now)
+		if err != nil {
+			return notified, err
+		}
+		if claimed {
+			notified++
+		}
+	}
+
+	return notified, nil
+}
+
+func (s *LoanService) notifyOverdue(ctx context.Context, loan model.Loan, now time.Time) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+	if err != nil {
+		return false, errorStruct.NewLocalizedError(errorStruct.ErrCodeTxFailed, i18n.MsgTxBeginFailed).WithCause(err)
+	}
+
+	defer tx.Rollback()
+
+	claimed, err := s.loanRepo.MarkOverdueNotified(ctx, tx, loan.ID, now)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	overdue := event.New(event.LoanOverdue, event.LoanAggregate(loan.ID), now.In(s.location), s.overdueLoan(loan, now))
+	if err := s.recordEvents(ctx, tx, overdue); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (s *LoanService) overdueLoan(loan model.Loan, now time.Time) dto.OverdueLoan {
+	dueAt := loan.BorrowedAt.AddDate(0, 0, s.policy.LoanPeriodDays)
+
+	return dto.OverdueLoan{
+		LoanID:      loan.ID,
+		MemberID:    loan.MemberID,
+		MemberName:  loan.MemberName,
+		MemberEmail: loan.MemberEmail,
+		BookID:      loan.BookID,
+		BookTitle:   loan.BookTitle,
+		BorrowedAt:  formatTimestamp(loan.BorrowedAt, s.location),
+		DueAt:       formatTimestamp(dueAt, s.location),
+		DaysOverdue: int(now.Sub(dueAt).Hours() / 24),
+	}
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/clock"
+	"github.com/Ar1veeee/library-api/internal/repository"
+	"github.com/Ar1veeee/library-api/internal/service"
+)
+
+// wib dipakai sebagai zona perpustakaan tanpa bergantung pada tzdata di mesin yang menjalankan test.
+var wib = time.FixedZone("WIB", 7*60*60)
+
+// fakeConn menjawab setiap query dengan baris yang sama (tidak habis setelah dibaca) dan mencatat argumen query terakhir,
+// cukup untuk method LoanService yang hanya membaca lewat satu query.
+type fakeConn struct {
+	rows [][]driver.Value
+	args *[]driver.Value
+}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c fakeConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	*c.args = (*c.args)[:0]
+	for _, arg := range args {
+		*c.args = append(*c.args, arg.Value)
+	}
+	return &fakeRows{rows: c.rows}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type fakeConnector struct {
+	conn fakeConn
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (fakeConnector) Driver() driver.Driver                          { return nil }
+
+// newLoanService membuat LoanService di atas fakeConn dengan masa pinjam 14 hari.
+// Slice yang dikembalikan berisi argumen query terakhir.
+func newLoanService(t *testing.T, c clock.Clock, rows ...[]driver.Value) (*service.LoanService, *[]driver.Value) {
+	t.Helper()
+
+	args := new([]driver.Value)
+	db := sql.OpenDB(fakeConnector{conn: fakeConn{rows: rows, args: args}})
+	t.Cleanup(func() { db.Close() })
+
+	loanService := service.NewLoanService(
+		db,
+		repository.NewBookRepository(db),
+		repository.NewMemberRepository(db),
+		repository.NewLoanRepository(db),
+		repository.NewFineRepository(db),
+		service.LoanPolicy{MaxActiveLoans: 3, LoanPeriodDays: 14},
+		wib,
+		c,
+		repository.NewOutboxRepository(db),
+		func() {},
+	)
+	return loanService, args
+}
+
+// TestLoanDueDateAndOverdueFlag memajukan jam sampai tepat jatuh tempo lalu satu nanodetik setelahnya:
+// pinjaman baru overdue setelah jatuh tempo terlewati, dan pinjaman yang sudah kembali tidak pernah overdue.
+func TestLoanDueDateAndOverdueFlag(t *testing.T) {
+	borrowedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	returnedAt := borrowedAt.Add(24 * time.Hour)
+	fixed := clock.NewFixed(borrowedAt)
+
+	loanService, _ := newLoanService(t, fixed,
+		[]driver.Value{int64(1), int64(7), int64(3), borrowedAt, nil},
+		[]driver.Value{int64(2), int64(7), int64(4), borrowedAt, returnedAt},
+	)
+
+	check := func(wantOverdue bool) {
+		t.Helper()
+		loans, err := loanService.GetLoansByMemberIDs(context.Background(), []int{7})
+		if err != nil {
+			t.Fatalf("GetLoansByMemberIDs: %v", err)
+		}
+		records := loans[7]
+		if len(records) != 2 {
+			t.Fatalf("got %d loans, want 2", len(records))
+		}
+		if want := "2026-03-15T17:00:00+07:00"; records[0].DueAt != want {
+			t.Errorf("DueAt = %q, want %q", records[0].DueAt, want)
+		}
+		if records[0].Overdue != wantOverdue {
+			t.Errorf("at %s: active loan Overdue = %v, want %v", fixed.Now(), records[0].Overdue, wantOverdue)
+		}
+		if records[1].Overdue {
+			t.Errorf("at %s: returned loan is overdue", fixed.Now())
+		}
+	}
+
+	check(false)
+
+	fixed.Advance(14 * 24 * time.Hour)
+	check(false)
+
+	fixed.Advance(time.Nanosecond)
+	check(true)
+}
+
+// TestListOverdueLoans memastikan batas borrowed_at yang dikirim ke repository berasal dari Clock,
+// dan DaysOverdue dibulatkan ke bawah ke hari penuh.
+func TestListOverdueLoans(t *testing.T) {
+	borrowedAt := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	row := []driver.Value{int64(1), int64(7), int64(3), borrowedAt, nil, "Dune", "Frank Herbert", "Tester", "tester@example.com"}
+
+	tests := []struct {
+		name     string
+		late     time.Duration
+		wantDays int
+	}{
+		{"less than a day late", 23 * time.Hour, 0},
+		{"one day and 23 hours late", 47 * time.Hour, 1},
+		{"exactly two days late", 48 * time.Hour, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed := clock.NewFixed(borrowedAt)
+			fixed.Advance(14*24*time.Hour + tt.late)
+			loanService, args := newLoanService(t, fixed, row)
+
+			loans, err := loanService.ListOverdueLoans(context.Background())
+			if err != nil {
+				t.Fatalf("ListOverdueLoans: %v", err)
+			}
+
+			wantCutoff := fixed.Now().AddDate(0, 0, -14)
+			if len(*args) != 1 {
+				t.Fatalf("GetOverdue got %d args, want 1", len(*args))
+			}
+			if cutoff, ok := (*args)[0].(time.Time); !ok || !cutoff.Equal(wantCutoff) {
+				t.Errorf("cutoff = %v, want %v", (*args)[0], wantCutoff)
+			}
+
+			if len(loans) != 1 {
+				t.Fatalf("got %d overdue loans, want 1", len(loans))
+			}
+			if want := "2026-03-18T17:00:00+07:00"; loans[0].DueAt != want {
+				t.Errorf("DueAt = %q, want %q", loans[0].DueAt, want)
+			}
+			if loans[0].DaysOverdue != tt.wantDays {
+				t.Errorf("DaysOverdue = %d, want %d", loans[0].DaysOverdue, tt.wantDays)
+			}
+		})
+	}
+}
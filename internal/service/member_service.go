@@ -2,21 +2,30 @@ package service
 
 import (
 	"context"
+	stdErrors "errors"
+	"strings"
+	"time"
 
 	"github.com/Ar1veeee/library-api/internal/dto"
 	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/model"
 	"github.com/Ar1veeee/library-api/internal/repository"
+	"github.com/Ar1veeee/library-api/internal/reqctx"
+	"github.com/Ar1veeee/library-api/internal/validation"
 )
 
 type MemberService struct {
 	memberRepo *repository.MemberRepository
 	loanRepo   *repository.LoanRepository
+	location   *time.Location
 }
 
-func NewMemberService(memberRepo *repository.MemberRepository, loanRepo *repository.LoanRepository) *MemberService {
+func NewMemberService(memberRepo *repository.MemberRepository, loanRepo *repository.LoanRepository, location *time.Location) *MemberService {
 	return &MemberService{
 		memberRepo: memberRepo,
 		loanRepo:   loanRepo,
+		location:   location,
 	}
 }
 
@@ -26,8 +35,9 @@ func (s *MemberService) GetMemberLoans(ctx context.Context, memberID int) (*dto.
 		return nil, err
 	}
 	if member == nil {
-		return nil, errors.NewAPIError("Member tidak ditemukan", errors.ErrCodeNotFound)
+		return nil, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgMemberNotFound)
 	}
+	reqctx.SetMemberLanguage(ctx, member.Language)
 
 	loans, err := s.loanRepo.GetByMemberID(ctx, memberID)
 	if err != nil {
@@ -44,7 +54,7 @@ func (s *MemberService) GetMemberLoans(ctx context.Context, memberID int) (*dto.
 		// - Client bisa membedakan "belum dikembalikan" (null) vs "dikembalikan tapi timestamp kosong".
 		var returnedAt *string
 		if loan.ReturnedAt != nil {
-			formatted := loan.ReturnedAt.Format("2006-01-02 15:04:05")
+			formatted := formatTimestamp(*loan.ReturnedAt, s.location)
 			returnedAt = &formatted
 		}
 
@@ -61,7 +71,7 @@ func (s *MemberService) GetMemberLoans(ctx context.Context, memberID int) (*dto.
 			BookID:     loan.BookID,
 			BookTitle:  loan.BookTitle,
 			BookAuthor: loan.BookAuthor,
-			BorrowedAt: loan.BorrowedAt.Format("2006-01-02 15:04:05"),
+			BorrowedAt: formatTimestamp(loan.BorrowedAt, s.location),
 			ReturnedAt: returnedAt,
 			Status:     status,
 		}
@@ -78,3 +88,53 @@ func (s *MemberService) GetMemberLoans(ctx context.Context, memberID int) (*dto.
 
 	return response, nil
 }
+
+// GetMembersByIDs mengambil banyak member dalam satu query, untuk data loader GraphQL.
+// ID yang tidak ditemukan tidak ada di map.
+func (s *MemberService) GetMembersByIDs(ctx context.Context, memberIDs []int) (map[int]dto.MemberResponse, error) {
+	members, err := s.memberRepo.GetByIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]dto.MemberResponse, len(members))
+	for _, member := range members {
+		result[member.ID] = dto.MemberResponse{
+			ID:       member.ID,
+			Name:     member.Name,
+			Email:    member.Email,
+			Language: member.Language,
+		}
+	}
+
+	return result, nil
+}
+
+// CreateMember mendaftarkan member baru.
+func (s *MemberService) CreateMember(ctx context.Context, req dto.CreateMemberRequest) (*dto.MemberResponse, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	// Aturan yang sama dengan request HTTP (tag validate di DTO), karena libctl memanggil service ini langsung.
+	if err := validation.Validate(req); err != nil {
+		return nil, err
+	}
+
+	member := &model.Member{Name: req.Name, Email: req.Email, Language: req.Language}
+
+	id, err := s.memberRepo.Create(ctx, member)
+	if err != nil {
+		if stdErrors.Is(err, repository.ErrDuplicateEntry) {
+			return nil, errors.NewLocalizedError(errors.ErrCodeInvalidInput, i18n.MsgMemberEmailTaken).
+				WithViolations(errors.NewViolation("email", "unique", i18n.MsgMemberEmailTaken))
+		}
+		return nil, errors.Internal("menyimpan member", err)
+	}
+
+	return &dto.MemberResponse{
+		ID:       int(id),
+		Name:     member.Name,
+		Email:    member.Email,
+		Language: member.Language,
+	}, nil
+}
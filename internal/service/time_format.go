@@ -0,0 +1,11 @@
+package service
+
+import "time"
+
+// formatTimestamp memformat waktu ke RFC 3339 dengan offset zona waktu perpustakaan (contoh 2026-01-05T14:30:00+07:00).
+// MENGAPA RFC 3339 dengan offset?
+// - Format "2006-01-02 15:04:05" tanpa zona membuat client tidak tahu apakah waktu itu UTC atau WIB
+// - Semua endpoint memakai helper ini, sehingga satu loan tampil dengan waktu yang sama di mana pun
+func formatTimestamp(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(time.RFC3339)
+}
@@ -0,0 +1,6 @@
+package service
+
+import "github.com/Ar1veeee/library-api/internal/tracing"
+
+// tracer membuat span untuk operasi bisnis (borrow/return), menjadi parent dari span repository.
+var tracer = tracing.Tracer("github.com/Ar1veeee/library-api/internal/service")
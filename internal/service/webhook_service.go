@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	stdErrors "errors"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/clock"
+	"github.com/Ar1veeee/library-api/internal/dto"
+	"github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/Ar1veeee/library-api/internal/model"
+	"github.com/Ar1veeee/library-api/internal/repository"
+	"github.com/Ar1veeee/library-api/internal/validation"
+	"github.com/Ar1veeee/library-api/internal/webhook"
+)
+
+// deliveryLogLimit adalah jumlah entry terbaru yang dikembalikan delivery log dan daftar dead-letter.
+const deliveryLogLimit = 100
+
+// WebhookService mengelola subscription webhook milik integrator. Pengiriman event ada di package webhook.
+type WebhookService struct {
+	repo     *repository.WebhookRepository
+	location *time.Location
+	clock    clock.Clock
+	// allowPrivateTargets mengizinkan URL loopback/link-local/private (pengujian lokal), lihat webhook.CheckTarget.
+	allowPrivateTargets bool
+}
+
+func NewWebhookService(repo *repository.WebhookRepository, location *time.Location, clock clock.Clock, allowPrivateTargets bool) *WebhookService {
+	return &WebhookService{repo: repo, location: location, clock: clock, allowPrivateTargets: allowPrivateTargets}
+}
+
+// CreateSubscription mendaftarkan endpoint integrator dan membuat secret HMAC-nya.
+// Secret hanya dikembalikan di response ini; integrator wajib menyimpannya untuk memverifikasi tanda tangan.
+func (s *WebhookService) CreateSubscription(ctx context.Context, req dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	if err := validation.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkTarget(ctx, req.URL); err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, errors.Internal("membuat secret webhook", err)
+	}
+
+	sub, err := s.repo.CreateSubscription(ctx, req.URL, secret, uniqueStrings(req.Events), s.clock.Now())
+	if err != nil {
+		return nil, errors.Internal("menyimpan webhook", err)
+	}
+
+	response := s.subscriptionResponse(*sub)
+	response.Secret = sub.Secret
+	return &response, nil
+}
+
+// checkTarget menolak URL yang mengarah ke jaringan internal (SSRF). Host yang tidak bisa di-resolve juga ditolak:
+// alamatnya tidak bisa diperiksa, dan pengiriman ke host tersebut toh akan gagal.
+func (s *WebhookService) checkTarget(ctx context.Context, url string) error {
+	if s.allowPrivateTargets {
+		return nil
+	}
+
+	err := webhook.CheckTarget(ctx, url)
+	switch {
+	case err == nil:
+		return nil
+	case stdErrors.Is(err, webhook.ErrPrivateTarget):
+		return errors.NewLocalizedError(errors.ErrCodeInvalidInput, i18n.MsgWebhookURLPrivate, "url").
+			WithViolations(errors.NewViolation("url", "public_host", i18n.MsgWebhookURLPrivate, "url"))
+	default:
+		return errors.NewLocalizedError(errors.ErrCodeInvalidInput, i18n.MsgWebhookURLUnresolvable, "url").
+			WithViolations(errors.NewViolation("url", "public_host", i18n.MsgWebhookURLUnresolvable, "url")).
+			WithCause(err)
+	}
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]dto.WebhookResponse, error) {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, errors.Internal("mengambil webhook", err)
+	}
+
+	responses := make([]dto.WebhookResponse, len(subs))
+	for i, sub := range subs {
+		responses[i] = s.subscriptionResponse(sub)
+	}
+	return responses, nil
+}
+
+// DeleteSubscription menonaktifkan subscription; pengiriman yang masih tertunda dipindahkan ke dead-letter.
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id int) error {
+	deactivated, err := s.repo.DeactivateSubscription(ctx, id)
+	if err != nil {
+		return errors.Internal("menonaktifkan webhook", err)
+	}
+	if !deactivated {
+		return errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgWebhookNotFound)
+	}
+	return nil
+}
+
+// ListDeliveries mengembalikan delivery log subscription, terbaru di atas.
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID int) ([]dto.WebhookDeliveryResponse, error) {
+	sub, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, errors.Internal("memeriksa webhook", err)
+	}
+	if sub == nil {
+		return nil, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgWebhookNotFound)
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, subscriptionID, deliveryLogLimit)
+	if err != nil {
+		return nil, errors.Internal("mengambil log pengiriman", err)
+	}
+	return s.deliveryResponses(deliveries), nil
+}
+
+func (s *WebhookService) ListDeadLetters(ctx context.Context) ([]dto.WebhookDeliveryResponse, error) {
+	deliveries, err := s.repo.ListDeadLetters(ctx, deliveryLogLimit)
+	if err != nil {
+		return nil, errors.Internal("mengambil dead-letter", err)
+	}
+	return s.deliveryResponses(deliveries), nil
+}
+
+// RetryDelivery mengembalikan pengiriman dead-letter ke antrean dengan jatah percobaan baru,
+// misalnya setelah integrator memperbaiki endpoint-nya.
+func (s *WebhookService) RetryDelivery(ctx context.Context, deliveryID int64) (*dto.WebhookDeliveryResponse, error) {
+	delivery, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, errors.Internal("memeriksa pengiriman", err)
+	}
+	if delivery == nil || delivery.Status != repository.DeliveryDead {
+		return nil, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgWebhookDeadLetterNotFound)
+	}
+
+	requeued, err := s.repo.Requeue(ctx, deliveryID, s.clock.Now())
+	if err != nil {
+		return nil, errors.Internal("menjadwalkan ulang pengiriman", err)
+	}
+	if !requeued {
+		// Delivery ada di dead-letter, tetapi subscription-nya sudah dinonaktifkan.
+		return nil, errors.NewLocalizedError(errors.ErrCodeNotFound, i18n.MsgWebhookNotFound)
+	}
+
+	delivery, err = s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil || delivery == nil {
+		return nil, errors.Internal("membaca pengiriman", err)
+	}
+	response := s.deliveryResponse(*delivery)
+	return &response, nil
+}
+
+func (s *WebhookService) subscriptionResponse(sub model.WebhookSubscription) dto.WebhookResponse {
+	return dto.WebhookResponse{
+		ID:        sub.ID,
+		URL:       sub.URL,
+		Events:    sub.Events,
+		Active:    sub.Active,
+		CreatedAt: formatTimestamp(sub.CreatedAt, s.location),
+	}
+}
+
+func (s *WebhookService) deliveryResponses(deliveries []model.WebhookDelivery) []dto.WebhookDeliveryResponse {
+	responses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = s.deliveryResponse(delivery)
+	}
+	return responses
+}
+
+func (s *WebhookService) deliveryResponse(d model.WebhookDelivery) dto.WebhookDeliveryResponse {
+	response := dto.WebhookDeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      formatTimestamp(d.CreatedAt, s.location),
+	}
+	// Jadwal percobaan hanya bermakna selama delivery masih di antrean.
+	if d.Status == repository.DeliveryPending {
+		nextAttemptAt := formatTimestamp(d.NextAttemptAt, s.location)
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if d.DeliveredAt != nil {
+		deliveredAt := formatTimestamp(*d.DeliveredAt, s.location)
+		response.DeliveredAt = &deliveredAt
+	}
+	return response
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// uniqueStrings membuang duplikat dengan mempertahankan urutan pertama kemunculan.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
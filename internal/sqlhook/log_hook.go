@@ -0,0 +1,41 @@
+package sqlhook
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/logger"
+)
+
+// LogHook mencatat setiap statement di level DEBUG (error di level WARN) beserta trace_id request.
+// Argumen query sengaja tidak dicatat agar data member (email, dsb.) tidak masuk ke log.
+type LogHook struct{}
+
+func (LogHook) After(ctx context.Context, q *Query, err error) {
+	log := logger.FromContext(ctx)
+
+	level := slog.LevelDebug
+	if err != nil {
+		level = slog.LevelWarn
+	}
+	if !log.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("op", q.Op),
+		slog.String("statement", compact(q.SQL)),
+		slog.Float64("duration_ms", float64(q.Duration.Microseconds())/1000),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	log.LogAttrs(ctx, level, "db query", attrs...)
+}
+
+// compact merapikan whitespace query multi-baris menjadi satu baris.
+func compact(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
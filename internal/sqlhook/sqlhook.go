@@ -0,0 +1,67 @@
+// Package sqlhook membungkus driver database/sql agar setiap query bisa diamati (log, tracing)
+// tanpa mengubah kode repository.
+//
+// MENGAPA di level driver, bukan di repository?
+//   - Semua query (termasuk dari migration & job) otomatis tercakup, tidak ada yang terlewat
+//   - Context query adalah context request, sehingga trace ID ikut terbawa ke log query
+package sqlhook
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// Query mendeskripsikan satu eksekusi statement.
+type Query struct {
+	Op       string // "exec" atau "query"
+	SQL      string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Hook dipanggil sekali setelah setiap statement selesai dijalankan driver.
+//
+// MENGAPA tidak ada Before?
+//   - go-sql-driver mengembalikan driver.ErrSkip untuk query berparameter (InterpolateParams=false), lalu
+//     database/sql mengulangnya lewat prepare. Hook yang dimulai sebelum percobaan pertama (misalnya span)
+//     tidak pernah diselesaikan pada jalur itu
+//   - Dengan satu panggilan setelah statement selesai, setiap statement yang benar-benar dijalankan tercatat
+//     tepat sekali; span dibuat mundur memakai Query.Start
+type Hook interface {
+	After(ctx context.Context, q *Query, err error)
+}
+
+// Wrap membungkus connector sehingga semua koneksi yang dibuat menjalankan hooks.
+func Wrap(c driver.Connector, hooks ...Hook) driver.Connector {
+	return &connector{Connector: c, hooks: hooks}
+}
+
+type connector struct {
+	driver.Connector
+	hooks []Hook
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &hookConn{Conn: conn, hooks: c.hooks}, nil
+}
+
+// run menjalankan fn lalu memanggil hooks.
+// driver.ErrSkip tidak dilaporkan karena database/sql akan mengulang lewat jalur prepare (yang juga di-hook).
+func run[T any](ctx context.Context, hooks []Hook, op, query string, fn func(ctx context.Context) (T, error)) (T, error) {
+	start := time.Now()
+	result, err := fn(ctx)
+	if err == driver.ErrSkip {
+		return result, err
+	}
+
+	q := &Query{Op: op, SQL: query, Start: start, Duration: time.Since(start)}
+	for _, h := range hooks {
+		h.After(ctx, q, err)
+	}
+	return result, err
+}
@@ -0,0 +1,150 @@
+package sqlhook_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/Ar1veeee/library-api/internal/sqlhook"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeConn meniru go-sql-driver dengan InterpolateParams=false: Exec/Query berparameter dijawab driver.ErrSkip
+// sehingga database/sql mengulangnya lewat Prepare.
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (fakeConn) ExecContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Result, error) {
+	if len(args) > 0 {
+		return nil, driver.ErrSkip
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (fakeConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	if len(args) > 0 {
+		return nil, driver.ErrSkip
+	}
+	return fakeRows{}, nil
+}
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return driver.RowsAffected(1), nil }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return fakeRows{}, nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string         { return []string{"id"} }
+func (fakeRows) Close() error              { return nil }
+func (fakeRows) Next([]driver.Value) error { return io.EOF }
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return nil }
+
+type countingHook struct {
+	calls []*sqlhook.Query
+	errs  []error
+}
+
+func (h *countingHook) After(_ context.Context, q *sqlhook.Query, err error) {
+	h.calls = append(h.calls, q)
+	h.errs = append(h.errs, err)
+}
+
+func TestHookCalledOnceForParameterizedStatements(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(db *sql.DB) error
+		op        string
+		forUpdate bool
+	}{
+		{
+			name: "exec",
+			run: func(db *sql.DB) error {
+				_, err := db.Exec(`UPDATE books SET stock = stock - 1 WHERE id = ?`, 1)
+				return err
+			},
+			op: "exec",
+		},
+		{
+			name: "query",
+			run: func(db *sql.DB) error {
+				rows, err := db.Query(`SELECT id FROM books WHERE id = ? FOR UPDATE`, 1)
+				if err != nil {
+					return err
+				}
+				return rows.Close()
+			},
+			op:        "query",
+			forUpdate: true,
+		},
+		{
+			name: "without args",
+			run: func(db *sql.DB) error {
+				_, err := db.Exec(`DELETE FROM books`)
+				return err
+			},
+			op: "exec",
+		},
+	}
+
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+			hook := &countingHook{}
+			db := sql.OpenDB(sqlhook.Wrap(fakeConnector{}, hook, sqlhook.TraceHook{}, sqlhook.LogHook{}))
+			defer db.Close()
+
+			if err := tt.run(db); err != nil {
+				t.Fatalf("statement failed: %v", err)
+			}
+
+			if len(hook.calls) != 1 {
+				t.Fatalf("After called %d times, want 1", len(hook.calls))
+			}
+			if hook.errs[0] != nil {
+				t.Errorf("After err = %v, want nil", hook.errs[0])
+			}
+			if hook.calls[0].Op != tt.op {
+				t.Errorf("Op = %q, want %q", hook.calls[0].Op, tt.op)
+			}
+			if hook.calls[0].Start.IsZero() {
+				t.Error("Start is zero")
+			}
+
+			// Span yang dimulai tetapi tidak pernah diakhiri adalah kebocoran dari jalur driver.ErrSkip.
+			if started, ended := len(recorder.Started()), len(recorder.Ended()); started != 1 || ended != 1 {
+				t.Fatalf("spans started=%d ended=%d, want 1 and 1", started, ended)
+			}
+
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, kv := range recorder.Ended()[0].Attributes() {
+				attrs[kv.Key] = kv.Value
+			}
+			if _, ok := attrs["db.duration_ms"]; !ok {
+				t.Error("span has no db.duration_ms attribute")
+			}
+			if got := attrs["db.for_update"].AsBool(); got != tt.forUpdate {
+				t.Errorf("db.for_update = %v, want %v", got, tt.forUpdate)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package sqlhook
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Ar1veeee/library-api/internal/sqlhook"
+
+// TraceHook membuat span OpenTelemetry untuk setiap statement SQL.
+// Durasi statement dicatat sebagai db.duration_ms agar bisa difilter di backend tracing, dan statement
+// FOR UPDATE ditandai db.for_update. Nilainya latency statement (termasuk menunggu row lock), bukan waktu
+// tunggu lock yang terukur terpisah: FOR UPDATE yang lambat menunjukkan kandidat contention, bukan buktinya.
+type TraceHook struct{}
+
+func (TraceHook) After(ctx context.Context, q *Query, err error) {
+	statement := compact(q.SQL)
+	operation := firstKeyword(statement)
+
+	_, span := otel.Tracer(tracerName).Start(ctx, "mysql "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(q.Start),
+		trace.WithAttributes(
+			semconv.DBSystemMySQL,
+			semconv.DBOperation(operation),
+			semconv.DBStatement(statement),
+		),
+	)
+	defer span.End(trace.WithTimestamp(q.Start.Add(q.Duration)))
+
+	span.SetAttributes(attribute.Float64("db.duration_ms", float64(q.Duration.Microseconds())/1000))
+	if isLockingRead(q.SQL) {
+		span.SetAttributes(attribute.Bool("db.for_update", true))
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+func firstKeyword(statement string) string {
+	if i := strings.IndexByte(statement, ' '); i > 0 {
+		return strings.ToUpper(statement[:i])
+	}
+	return strings.ToUpper(statement)
+}
+
+func isLockingRead(query string) bool {
+	return strings.Contains(strings.ToUpper(query), "FOR UPDATE")
+}
@@ -0,0 +1,264 @@
+// Package stockstream menyalurkan perubahan stok buku (tabel stock_changes) ke client stream SSE.
+//
+// Alur: BookRepository mencatat setiap perubahan stok di transaksinya, Hub membaca catatan yang sudah commit
+// lalu membagikannya ke semua subscriber di proses ini (event bus in-process).
+//
+// MENGAPA polling database, bukan hanya notifikasi in-process?
+//   - Peminjaman di instance API lain (atau lewat libctl) tidak terlihat oleh proses ini; polling stock_changes
+//     membuat semua instance melihat perubahan yang sama
+//   - Satu poller per instance, bukan per client: jumlah client SSE tidak menambah beban database
+//   - Perubahan dari instance yang sama tidak menunggu poll: LoanService memanggil Wake setelah commit
+//
+// Urutan dan celah id:
+//   - Auto-increment dibagikan saat INSERT, bukan saat COMMIT, sehingga id 11 bisa terlihat sebelum id 10 commit.
+//     Hub hanya maju secara berurutan (cursor+1); celah ditunggu sampai gapTimeout, setelah itu dianggap
+//     transaksi yang di-rollback dan dilewati
+//   - Karena itu urutan stream sama dengan urutan id, dan Last-Event-ID cukup untuk melanjutkan stream
+package stockstream
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/metrics"
+	"github.com/Ar1veeee/library-api/internal/model"
+	"github.com/Ar1veeee/library-api/internal/repository"
+)
+
+const (
+	// pollBatch adalah jumlah perubahan yang dibaca dalam satu poll.
+	pollBatch = 500
+	// replayBatch adalah jumlah perubahan per halaman saat client melanjutkan stream dari Last-Event-ID.
+	replayBatch = 500
+	// subscriberBuffer adalah jumlah perubahan yang boleh tertahan per client sebelum stream-nya diputus.
+	subscriberBuffer = 64
+	// gapTimeout adalah batas menunggu id yang hilang. Transaksi borrow/return hanya berjalan beberapa milidetik
+	// setelah stok berubah, sehingga celah yang bertahan selama ini hampir pasti rollback.
+	gapTimeout = 10 * time.Second
+)
+
+// Subscription menerima perubahan stok satu buku (atau semua buku jika bookID 0).
+// Channel ditutup saat Hub berhenti atau client terlalu lambat; client lalu menyambung ulang dengan Last-Event-ID.
+type Subscription struct {
+	bookID int
+	ch     chan model.StockChange
+	closed bool // dijaga Hub.mu
+}
+
+func (s *Subscription) Changes() <-chan model.StockChange {
+	return s.ch
+}
+
+type Hub struct {
+	repo         *repository.StockChangeRepository
+	pollInterval time.Duration
+	log          *slog.Logger
+	wake         chan struct{}
+	// ready ditutup setelah cursor awal terbaca; sebelum itu Subscribe menunggu.
+	ready chan struct{}
+	// gapSince adalah saat celah id pertama kali terlihat (hanya diakses goroutine Run).
+	gapSince time.Time
+
+	mu     sync.Mutex
+	cursor int64
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+func NewHub(repo *repository.StockChangeRepository, pollInterval time.Duration, log *slog.Logger) *Hub {
+	return &Hub{
+		repo:         repo,
+		pollInterval: pollInterval,
+		log:          log,
+		wake:         make(chan struct{}, 1),
+		ready:        make(chan struct{}),
+		subs:         make(map[*Subscription]struct{}),
+	}
+}
+
+// Wake meminta Hub segera membaca perubahan baru. Tidak pernah memblokir pemanggil.
+func (h *Hub) Wake() {
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run membaca perubahan stok sampai ctx dibatalkan, lalu menutup semua subscription
+// sehingga stream SSE berakhir dan client menyambung ke instance lain.
+func (h *Hub) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+	defer h.close()
+
+	started := false
+	for {
+		if !started {
+			started = h.start(ctx)
+		} else {
+			// Batch penuh berarti masih ada perubahan tersisa; lanjutkan tanpa menunggu poll berikutnya.
+			for ctx.Err() == nil && h.poll(ctx) == pollBatch {
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-h.wake:
+		}
+	}
+}
+
+// start mengambil titik awal stream: perubahan sebelum Hub berjalan hanya tersedia lewat replay Last-Event-ID.
+func (h *Hub) start(ctx context.Context) bool {
+	latest, err := h.repo.LatestID(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			h.log.Error("failed to start stock stream", "error", err)
+		}
+		return false
+	}
+
+	h.mu.Lock()
+	h.cursor = latest
+	h.mu.Unlock()
+	close(h.ready)
+	return true
+}
+
+// poll membagikan perubahan yang sudah commit secara berurutan dan mengembalikan jumlahnya.
+func (h *Hub) poll(ctx context.Context) int {
+	// cursor hanya diubah oleh goroutine ini, sehingga aman dibaca tanpa lock.
+	cursor := h.cursor
+
+	changes, err := h.repo.ListSince(ctx, 0, cursor, 0, pollBatch)
+	if err != nil {
+		if ctx.Err() == nil {
+			h.log.Error("failed to read stock changes", "error", err)
+		}
+		return 0
+	}
+
+	now := time.Now()
+	ready := make([]model.StockChange, 0, len(changes))
+	for _, change := range changes {
+		// cursor 0 berarti tabel kosong saat Hub mulai (misalnya setelah pruning), sedangkan auto-increment
+		// tidak ikut kembali ke 1; perubahan pertama menjadi titik awal tanpa menunggu celah.
+		if cursor > 0 && change.ID != cursor+1 {
+			if h.gapSince.IsZero() {
+				h.gapSince = now
+			}
+			if now.Sub(h.gapSince) < gapTimeout {
+				break
+			}
+			h.log.Warn("stock stream skipped missing change ids", "from", cursor+1, "to", change.ID-1)
+		}
+		h.gapSince = time.Time{}
+		ready = append(ready, change)
+		cursor = change.ID
+	}
+
+	h.publish(ready, cursor)
+	return len(ready)
+}
+
+func (h *Hub) publish(changes []model.StockChange, cursor int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, change := range changes {
+		for sub := range h.subs {
+			if sub.bookID != 0 && sub.bookID != change.BookID {
+				continue
+			}
+			select {
+			case sub.ch <- change:
+			default:
+				// Client lambat tidak boleh menahan client lain; stream-nya diputus dan dilanjutkan
+				// dari Last-Event-ID (replay dari database) saat menyambung ulang.
+				h.remove(sub)
+			}
+		}
+	}
+	h.cursor = cursor
+	metrics.SetStockStreamSubscribers(len(h.subs))
+}
+
+// Subscribe mendaftarkan client dan mengembalikan cursor saat itu: semua perubahan dengan id > cursor dikirim
+// lewat Subscription, sedangkan perubahan sebelumnya dibaca dengan Replay.
+// Jika Hub sudah berhenti, Subscription yang dikembalikan sudah tertutup.
+func (h *Hub) Subscribe(ctx context.Context, bookID int) (*Subscription, int64, error) {
+	select {
+	case <-h.ready:
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &Subscription{bookID: bookID, ch: make(chan model.StockChange, subscriberBuffer)}
+	if h.closed {
+		sub.closed = true
+		close(sub.ch)
+		return sub, h.cursor, nil
+	}
+
+	h.subs[sub] = struct{}{}
+	metrics.SetStockStreamSubscribers(len(h.subs))
+	return sub, h.cursor, nil
+}
+
+// Unsubscribe menghentikan Subscription; aman dipanggil lebih dari sekali.
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(sub)
+	metrics.SetStockStreamSubscribers(len(h.subs))
+}
+
+// Replay memanggil fn untuk setiap perubahan dengan afterID < id <= untilID secara berurutan.
+func (h *Hub) Replay(ctx context.Context, bookID int, afterID, untilID int64, fn func(model.StockChange) error) error {
+	for afterID < untilID {
+		changes, err := h.repo.ListSince(ctx, bookID, afterID, untilID, replayBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			if err := fn(change); err != nil {
+				return err
+			}
+			afterID = change.ID
+		}
+		if len(changes) < replayBatch {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (h *Hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subs {
+		h.remove(sub)
+	}
+	metrics.SetStockStreamSubscribers(0)
+}
+
+// remove dipanggil dengan h.mu terkunci.
+func (h *Hub) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.ch)
+	delete(h.subs, sub)
+}
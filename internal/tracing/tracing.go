@@ -0,0 +1,123 @@
+// Package tracing menyiapkan OpenTelemetry tracing (provider, exporter, propagator)
+// dan helper untuk menutup span dengan status yang konsisten.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ar1veeee/library-api/internal/buildinfo"
+	errorStruct "github.com/Ar1veeee/library-api/internal/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceName = "library-api"
+
+// Options mengatur exporter tracing.
+type Options struct {
+	// Exporter: "none" (default), "stdout" untuk development lokal, atau "otlp" (OTLP/HTTP).
+	Exporter string
+	// OTLPEndpoint contoh "otel-collector:4318". Kosong = pakai env standar OTEL_EXPORTER_OTLP_ENDPOINT.
+	OTLPEndpoint string
+	// OTLPInsecure mengirim tanpa TLS (umum untuk collector di jaringan internal).
+	OTLPInsecure bool
+	// SampleRatio adalah rasio trace yang disimpan (0..1), mengikuti keputusan sampling parent jika ada.
+	SampleRatio float64
+}
+
+// Setup memasang tracer provider global dan propagator W3C Trace Context.
+// Mengembalikan fungsi shutdown yang wajib dipanggil saat aplikasi berhenti agar span yang tersisa ter-flush.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	// Propagator tetap dipasang walaupun exporter "none",
+	// agar traceparent dari client tetap diteruskan (misalnya ke log atau service lain).
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch strings.ToLower(opts.Exporter) {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "otlp":
+		var clientOpts []otlptracehttp.Option
+		if opts.OTLPEndpoint != "" {
+			clientOpts = append(clientOpts, otlptracehttp.WithEndpoint(opts.OTLPEndpoint))
+		}
+		if opts.OTLPInsecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, clientOpts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(buildinfo.Get().Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer mengembalikan tracer dari provider global.
+// Alasan tidak menyimpan provider di struct: kode instrumentasi tetap jalan (no-op) ketika tracing dimatikan.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// End menutup span dan menandai status berdasarkan error.
+// MENGAPA penolakan bisnis tidak ditandai sebagai error?
+// - Stok habis / kuota penuh adalah hasil normal, bukan kegagalan sistem
+// - Jika ditandai error, dashboard error-rate tracing akan penuh noise dan kegagalan DB sulit terlihat
+func End(span trace.Span, err error) {
+	defer span.End()
+
+	if err == nil {
+		return
+	}
+
+	var apiErr errorStruct.APIError
+	if errors.As(err, &apiErr) {
+		span.SetAttributes(attribute.String("app.error_code", apiErr.ZiyadErrCode))
+		if apiErr.ZiyadErrCode != errorStruct.ErrCodeTxFailed {
+			return
+		}
+		// Message sudah disanitasi untuk client; backend tracing bersifat internal, jadi catat penyebab aslinya.
+		if apiErr.Cause != nil {
+			err = apiErr.Cause
+		}
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
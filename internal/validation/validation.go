@@ -0,0 +1,131 @@
+// Package validation menegakkan tag `validate` pada DTO request (misalnya dto.BorrowBookRequest)
+// dan menerjemahkan hasilnya menjadi pelanggaran per field yang bisa ditampilkan ke client.
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	apiErrors "github.com/Ar1veeee/library-api/internal/errors"
+	"github.com/Ar1veeee/library-api/internal/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError adalah satu pelanggaran aturan validasi pada satu field.
+type FieldError struct {
+	// Field memakai nama JSON (member_id), bukan nama struct Go (MemberID), agar cocok dengan body request.
+	Field string
+	// Rule adalah nama tag yang dilanggar, misalnya required, gt, email.
+	Rule string
+	// Param adalah parameter aturan, misalnya "0" untuk gt=0.
+	Param   string
+	Message string
+
+	// MessageID & Args adalah bentuk Message di catalog i18n, agar bisa diterjemahkan saat response ditulis.
+	MessageID string
+	Args      []any
+}
+
+// validate di-share karena validator.Validate meng-cache metadata struct dan aman dipakai concurrent.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Nama field diambil dari tag json, sehingga pesan error menyebut "member_id" seperti yang dikirim client.
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	return v
+}
+
+// Struct memvalidasi s berdasarkan tag `validate` dan mengembalikan semua pelanggaran (nil jika valid).
+func Struct(s any) []FieldError {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		// InvalidValidationError hanya terjadi jika s bukan struct, yaitu bug di pemanggil.
+		panic(err)
+	}
+
+	fieldErrors := make([]FieldError, len(validationErrors))
+	for i, fe := range validationErrors {
+		messageID, args := message(fieldPath(fe), fe.Tag(), fe.Param())
+		fieldErrors[i] = FieldError{
+			Field:     fieldPath(fe),
+			Rule:      fe.Tag(),
+			Param:     fe.Param(),
+			Message:   i18n.T(i18n.Default, messageID, args...),
+			MessageID: messageID,
+			Args:      args,
+		}
+	}
+
+	return fieldErrors
+}
+
+// fieldPath membuang nama struct root, contoh: BorrowBookRequest.member_id → member_id.
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+// message memilih pesan catalog i18n untuk aturan yang dipakai DTO di repo ini.
+func message(field, rule, param string) (string, []any) {
+	switch rule {
+	case "required":
+		return i18n.MsgValidationRequired, []any{field}
+	case "gt":
+		return i18n.MsgValidationGT, []any{field, param}
+	case "gte", "min":
+		return i18n.MsgValidationMin, []any{field, param}
+	case "lte", "max":
+		return i18n.MsgValidationMax, []any{field, param}
+	case "email":
+		return i18n.MsgValidationEmail, []any{field}
+	case "oneof":
+		return i18n.MsgValidationOneOf, []any{field, strings.ReplaceAll(param, " ", ", ")}
+	case "http_url":
+		return i18n.MsgValidationURL, []any{field}
+	default:
+		return i18n.MsgValidationInvalid, []any{field, rule}
+	}
+}
+
+// Validate menjalankan Struct dan mengubah hasilnya menjadi APIError ErrCodeInvalidInput berisi violations,
+// atau nil jika s valid. Dipakai oleh mapper.DecodeJSON dan service yang juga dipanggil dari libctl.
+func Validate(s any) error {
+	fieldErrors := Struct(s)
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	violations := make([]apiErrors.Violation, len(fieldErrors))
+	messages := make([]string, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		violations[i] = apiErrors.NewViolation(fe.Field, fe.Rule, fe.MessageID, fe.Args...)
+		messages[i] = fe.Message
+	}
+
+	return apiErrors.NewLocalizedError(
+		apiErrors.ErrCodeInvalidInput,
+		i18n.MsgValidationFailed,
+		strings.Join(messages, "; "),
+	).WithViolations(violations...)
+}
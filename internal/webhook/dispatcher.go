@@ -0,0 +1,232 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Ar1veeee/library-api/internal/buildinfo"
+	"github.com/Ar1veeee/library-api/internal/clock"
+	"github.com/Ar1veeee/library-api/internal/metrics"
+	"github.com/Ar1veeee/library-api/internal/model"
+	"github.com/Ar1veeee/library-api/internal/repository"
+)
+
+// Options mengatur jadwal dan retry Dispatcher.
+type Options struct {
+	// PollInterval adalah jeda memeriksa antrean jika tidak dibangunkan Publisher (retry & event dari instance lain).
+	PollInterval time.Duration
+	// Timeout adalah batas satu request HTTP ke integrator.
+	Timeout time.Duration
+	// MaxAttempts adalah jumlah percobaan sebelum delivery masuk dead-letter.
+	MaxAttempts int
+	// Jeda retry ke-n adalah BackoffBase * 2^(n-1), paling lama BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// AllowPrivateTargets mematikan penolakan alamat loopback/link-local/private (lihat CheckTarget),
+	// untuk pengujian lokal dengan receiver di 127.0.0.1.
+	AllowPrivateTargets bool
+}
+
+// batchSize adalah jumlah delivery yang diklaim dan dikirim bersamaan dalam satu putaran.
+const batchSize = 20
+
+// maxResponseBytes membatasi body response integrator yang dibaca (isinya tidak dipakai, hanya dikuras
+// agar koneksi bisa dipakai ulang).
+const maxResponseBytes = 64 << 10
+
+type Dispatcher struct {
+	repo   *repository.WebhookRepository
+	clock  clock.Clock
+	client *http.Client
+	opts   Options
+	log    *slog.Logger
+	wake   chan struct{}
+}
+
+func NewDispatcher(repo *repository.WebhookRepository, clock clock.Clock, opts Options, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		repo:  repo,
+		clock: clock,
+		client: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: newTransport(opts.AllowPrivateTargets),
+			// Redirect tidak diikuti: endpoint webhook harus menjawab langsung, dan payload bertanda tangan
+			// tidak boleh diteruskan ke host lain. Response 3xx dihitung sebagai kegagalan.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		opts: opts,
+		log:  log,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// newTransport menyalin http.DefaultTransport dan, kecuali allowPrivate, menolak koneksi ke alamat
+// loopback/link-local/private saat dial.
+// Proxy dari environment dimatikan karena yang di-dial adalah proxy, bukan integrator, sehingga cek alamat
+// menjadi tidak berarti (dan proxy di jaringan internal akan selalu ditolak).
+func newTransport(allowPrivate bool) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if allowPrivate {
+		return transport
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialControl}
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+	return transport
+}
+
+// Wake meminta Dispatcher segera memeriksa antrean. Tidak pernah memblokir pemanggil.
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run mengirim delivery yang jatuh tempo sampai ctx dibatalkan. Request yang sedang berjalan saat shutdown
+// diselesaikan dulu (dibatasi Timeout), sehingga hasilnya tetap tercatat dan tidak dikirim ulang tanpa perlu.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Batch penuh berarti antrean mungkin masih berisi; lanjutkan tanpa menunggu poll berikutnya.
+		for ctx.Err() == nil && d.dispatchBatch(ctx) == batchSize {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchBatch(ctx context.Context) int {
+	now := d.clock.Now()
+	token := newClaimToken()
+
+	// Lease lebih panjang dari Timeout agar delivery yang masih dikirim tidak diklaim worker lain.
+	deliveries, err := d.repo.ClaimDue(ctx, token, now, now.Add(d.opts.Timeout+time.Minute), batchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.log.Error("failed to claim webhook deliveries", "error", err)
+		}
+		return 0
+	}
+
+	sendCtx := context.WithoutCancel(ctx)
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery model.WebhookDelivery) {
+			defer wg.Done()
+			d.deliver(sendCtx, token, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, token string, delivery model.WebhookDelivery) {
+	log := d.log.With(
+		"delivery_id", delivery.ID,
+		"subscription_id", delivery.SubscriptionID,
+		"event_id", delivery.EventID,
+		"event_type", delivery.EventType,
+	)
+
+	statusCode, sendErr := d.send(ctx, delivery)
+	now := d.clock.Now()
+
+	if sendErr == nil {
+		if err := d.repo.MarkDelivered(ctx, delivery.ID, token, statusCode, now); err != nil {
+			log.Error("failed to record webhook delivery", "error", err)
+		}
+		metrics.ObserveWebhookDelivery(delivery.EventType, "delivered")
+		log.Debug("webhook delivered", "status_code", statusCode)
+		return
+	}
+
+	attempt := delivery.Attempts + 1
+	dead := attempt >= d.opts.MaxAttempts
+	nextAttemptAt := now.Add(Backoff(attempt, d.opts.BackoffBase, d.opts.BackoffMax))
+
+	var lastStatusCode *int
+	if statusCode > 0 {
+		lastStatusCode = &statusCode
+	}
+	if err := d.repo.MarkFailed(ctx, delivery.ID, token, lastStatusCode, sendErr.Error(), nextAttemptAt, dead); err != nil {
+		log.Error("failed to record webhook failure", "error", err)
+	}
+
+	if dead {
+		metrics.ObserveWebhookDelivery(delivery.EventType, "dead")
+		log.Error("webhook moved to dead-letter", "attempts", attempt, "error", sendErr)
+		return
+	}
+	metrics.ObserveWebhookDelivery(delivery.EventType, "retry")
+	log.Warn("webhook delivery failed, will retry", "attempts", attempt, "next_attempt_at", nextAttemptAt, "error", sendErr)
+}
+
+// send mengirim payload dan mengembalikan status HTTP (0 jika tidak ada response). Hanya 2xx dianggap berhasil.
+func (d *Dispatcher) send(ctx context.Context, delivery model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "library-api-webhook/"+buildinfo.Version)
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	// Tanda tangan memakai jam sistem, bukan clock aplikasi: penerima membandingkannya dengan jam mereka sendiri,
+	// sehingga offset "time travel" di staging tidak boleh ikut.
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, time.Now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Backoff menghitung jeda sebelum percobaan berikutnya setelah percobaan ke-attempt gagal:
+// base, 2×base, 4×base, ... dibatasi max.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+func newClaimToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
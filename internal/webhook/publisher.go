@@ -0,0 +1,94 @@
+// Package webhook mengirim domain event (internal/event) ke endpoint integrator yang berlangganan.
+//
+// Alur: Publisher menyimpan satu baris webhook_deliveries per subscription yang cocok, lalu Dispatcher
+// mengirimnya sebagai JSON bertanda tangan HMAC dengan retry & exponential backoff. Pengiriman yang terus gagal
+// masuk dead-letter (status "dead") dan bisa di-retry manual lewat API.
+//
+// Urutan: delivery satu subscription untuk aggregate yang sama (misalnya loan:12) dikirim berurutan. Delivery
+// berikutnya baru diklaim setelah yang sebelumnya delivered atau dead, sehingga integrator tidak pernah menerima
+// loan.returned sebelum loan.created untuk pinjaman yang sama (kecuali loan.created masuk dead-letter).
+//
+// MENGAPA lewat tabel, bukan langsung HTTP dari request borrow/return?
+//   - Integrator yang lambat atau mati tidak boleh memperlambat/menggagalkan peminjaman
+//   - Retry tetap berjalan walaupun API di-restart, dan delivery log bisa dibaca integrator
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ar1veeee/library-api/internal/clock"
+	"github.com/Ar1veeee/library-api/internal/event"
+	"github.com/Ar1veeee/library-api/internal/model"
+	"github.com/Ar1veeee/library-api/internal/repository"
+)
+
+// Publisher mengantrekan event untuk semua subscription aktif yang berlangganan tipe event tersebut.
+type Publisher struct {
+	repo  *repository.WebhookRepository
+	clock clock.Clock
+	// wake membangunkan Dispatcher di proses yang sama agar event terkirim tanpa menunggu poll berikutnya (opsional).
+	wake func()
+}
+
+// NewPublisher membuat Publisher. wake boleh nil, misalnya di libctl yang tidak menjalankan Dispatcher;
+// delivery tetap dikirim oleh Dispatcher di instance API.
+func NewPublisher(repo *repository.WebhookRepository, clock clock.Clock, wake func()) *Publisher {
+	return &Publisher{repo: repo, clock: clock, wake: wake}
+}
+
+func (p *Publisher) Publish(ctx context.Context, events ...event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	subs, err := p.repo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+
+	now := p.clock.Now()
+	var deliveries []model.WebhookDelivery
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("encode event %s: %w", e.Type, err)
+		}
+
+		for _, sub := range subs {
+			if !subscribed(sub, e.Type) {
+				continue
+			}
+			deliveries = append(deliveries, model.WebhookDelivery{
+				SubscriptionID: sub.ID,
+				EventID:        e.ID,
+				EventType:      string(e.Type),
+				Aggregate:      e.Aggregate,
+				Payload:        payload,
+				NextAttemptAt:  now,
+			})
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := p.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("enqueue webhook deliveries: %w", err)
+	}
+
+	if p.wake != nil {
+		p.wake()
+	}
+	return nil
+}
+
+func subscribed(sub model.WebhookSubscription, eventType event.Type) bool {
+	for _, e := range sub.Events {
+		if e == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
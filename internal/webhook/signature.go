@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Header yang dikirim bersama setiap payload webhook.
+const (
+	HeaderSignature = "X-Library-Signature"
+	HeaderEvent     = "X-Library-Event"
+	HeaderDelivery  = "X-Library-Delivery"
+)
+
+// Sign menghasilkan nilai header X-Library-Signature: "t=<unix>,v1=<hex HMAC-SHA256(secret, "<unix>.<body>")>".
+//
+// MENGAPA timestamp ikut ditandatangani?
+//   - Penerima bisa menolak payload lama (misalnya lebih dari 5 menit) sehingga request yang direkam tidak bisa
+//     dikirim ulang (replay) oleh pihak lain
+//   - Timestamp dibuat saat setiap percobaan, sehingga retry setelah backoff panjang tetap lolos pengecekan tersebut
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
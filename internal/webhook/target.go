@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+// ErrPrivateTarget dikembalikan jika URL webhook mengarah ke alamat loopback, link-local, atau private.
+var ErrPrivateTarget = errors.New("webhook target resolves to a loopback, link-local, or private address")
+
+// CheckTarget me-resolve host URL webhook dan menolaknya (ErrPrivateTarget) jika salah satu alamatnya
+// loopback, link-local, atau private. Dipanggil saat subscription dibuat agar integrator langsung tahu URL-nya ditolak.
+//
+// MENGAPA dicek lagi saat dial (lihat dialControl)?
+//   - DNS bisa berubah setelah subscription dibuat (DNS rebinding): host yang tadinya publik bisa diarahkan ke
+//     127.0.0.1 atau metadata cloud (169.254.169.254), sehingga cek saat pendaftaran saja tidak cukup
+func CheckTarget(ctx context.Context, rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", target.Hostname())
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if blockedAddr(addr) {
+			return ErrPrivateTarget
+		}
+	}
+	return nil
+}
+
+// dialControl dipasang sebagai net.Dialer.Control pada client Dispatcher. address sudah berupa IP hasil resolve,
+// sehingga yang diperiksa adalah alamat yang benar-benar dihubungi.
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if blockedAddr(addrPort.Addr()) {
+		return ErrPrivateTarget
+	}
+	return nil
+}
+
+// sharedAddressSpace adalah 100.64.0.0/10 (RFC 6598, CGNAT). netip.Addr.IsPrivate tidak mencakupnya, padahal
+// di banyak cloud dan jaringan internal rentang ini dipakai untuk layanan yang tidak boleh dijangkau dari luar.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// blockedAddr juga menolak alamat unspecified (0.0.0.0, ::) karena dial ke alamat itu sampai ke host lokal.
+func blockedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsPrivate() ||
+		sharedAddressSpace.Contains(addr) ||
+		addr.IsUnspecified()
+}
@@ -0,0 +1,42 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ar1veeee/library-api/internal/webhook"
+)
+
+// TestCheckTarget memakai IP literal agar tidak bergantung pada DNS.
+func TestCheckTarget(t *testing.T) {
+	tests := []struct {
+		url     string
+		blocked bool
+	}{
+		{"http://127.0.0.1:9000/hook", true},
+		{"http://[::1]/hook", true},
+		{"http://169.254.169.254/latest/meta-data", true},
+		{"http://10.0.0.5/hook", true},
+		{"http://172.16.0.1/hook", true},
+		{"https://192.168.1.10/hook", true},
+		{"http://[fd00::1]/hook", true},
+		{"http://[::ffff:127.0.0.1]/hook", true},
+		{"http://0.0.0.0/hook", true},
+		{"http://100.64.1.2/hook", true},
+		{"http://[::ffff:100.127.255.254]/hook", true},
+		{"https://100.128.0.1/hook", false},
+		{"https://93.184.215.14/hook", false},
+		{"https://[2606:4700::1111]/hook", false},
+	}
+
+	for _, tt := range tests {
+		err := webhook.CheckTarget(context.Background(), tt.url)
+		if blocked := errors.Is(err, webhook.ErrPrivateTarget); blocked != tt.blocked {
+			t.Errorf("CheckTarget(%q) = %v, want blocked=%v", tt.url, err, tt.blocked)
+		}
+		if !tt.blocked && err != nil {
+			t.Errorf("CheckTarget(%q) = %v, want nil", tt.url, err)
+		}
+	}
+}
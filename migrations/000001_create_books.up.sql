@@ -0,0 +1,15 @@
+-- Table: books
+CREATE TABLE IF NOT EXISTS books
+(
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    title      VARCHAR(255) NOT NULL,
+    author     VARCHAR(255) NOT NULL,
+    stock      INT          NOT NULL DEFAULT 0,
+    created_at TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP             DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    -- MENGAPA index pada stock?
+    -- Query "cek stok > 0" sangat sering, index mempercepat lookup
+    INDEX idx_stock (stock)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
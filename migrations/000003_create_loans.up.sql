@@ -0,0 +1,23 @@
+-- Table: loans
+CREATE TABLE IF NOT EXISTS loans
+(
+    id          INT AUTO_INCREMENT PRIMARY KEY,
+    member_id   INT       NOT NULL,
+    book_id     INT       NOT NULL,
+    borrowed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    returned_at TIMESTAMP NULL,
+
+    FOREIGN KEY (member_id) REFERENCES members (id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+
+    -- MENGAPA composite index (member_id, returned_at)?
+    -- Query "hitung pinjaman aktif member" sangat sering (validasi kuota)
+    -- WHERE member_id = X AND returned_at IS NULL
+    INDEX idx_member_active (member_id, returned_at),
+
+    -- MENGAPA composite index (member_id, book_id, returned_at)?
+    -- Query "cek apakah member sedang pinjam buku ini" untuk prevent double borrow
+    -- WHERE member_id = X AND book_id = Y AND returned_at IS NULL
+    INDEX idx_member_book_active (member_id, book_id, returned_at)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,12 @@
+-- Kolom hanya dihapus jika masih ada, sehingga rollback aman diulang (lihat catatan di script up).
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'books'
+       AND COLUMN_NAME = 'total_copies') > 0,
+    'ALTER TABLE books DROP COLUMN total_copies',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
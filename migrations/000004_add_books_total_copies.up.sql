@@ -0,0 +1,25 @@
+-- MENGAPA total_copies?
+-- Kolom stock hanya menyimpan stok yang tersedia (berkurang saat dipinjam), sehingga jumlah eksemplar
+-- yang dimiliki perpustakaan tidak tercatat. Tanpa total_copies, stok yang terlanjur tidak konsisten
+-- (misalnya karena update manual) tidak bisa dihitung ulang.
+-- MySQL tidak punya ADD COLUMN IF NOT EXISTS: kolom hanya ditambahkan jika belum ada di information_schema,
+-- sehingga script aman diulang jika proses mati sebelum versinya tercatat di schema_migrations.
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'books'
+       AND COLUMN_NAME = 'total_copies') = 0,
+    'ALTER TABLE books ADD COLUMN total_copies INT NOT NULL DEFAULT 0 AFTER author',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
+
+-- Inisialisasi dari data yang ada: eksemplar = stok tersedia + pinjaman aktif.
+-- Aman diulang bersama ALTER di atas: nilainya selalu dihitung ulang, bukan ditambahkan.
+UPDATE books b
+SET b.total_copies = b.stock + (SELECT COUNT(*)
+                                FROM loans l
+                                WHERE l.book_id = b.id
+                                  AND l.returned_at IS NULL);
@@ -0,0 +1,17 @@
+-- Preferensi bahasa pesan API per member (id/en).
+-- MENGAPA NULL, bukan DEFAULT 'id'?
+-- NULL berarti "belum memilih", sehingga default bahasa tetap ditentukan aplikasi (i18n.Default)
+-- dan bisa diubah tanpa migration data.
+-- MySQL tidak punya ADD COLUMN IF NOT EXISTS: kolom hanya ditambahkan jika belum ada di information_schema,
+-- sehingga script aman diulang jika proses mati sebelum versinya tercatat di schema_migrations.
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'members'
+       AND COLUMN_NAME = 'language') = 0,
+    'ALTER TABLE members ADD COLUMN language VARCHAR(5) NULL AFTER email',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
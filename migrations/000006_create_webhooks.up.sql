@@ -0,0 +1,42 @@
+-- Webhook: langganan integrator dan log pengiriman event.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions
+(
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    url        VARCHAR(2048) NOT NULL,
+    -- secret dipakai untuk tanda tangan HMAC, sehingga harus disimpan dalam bentuk asli (bukan hash).
+    secret     VARCHAR(128)  NOT NULL,
+    -- Daftar event dipisah koma, misalnya "loan.created,loan.returned".
+    -- MENGAPA bukan tabel terpisah? Jumlah event sedikit dan selalu dibaca bersama subscription-nya.
+    events     VARCHAR(255)  NOT NULL,
+    active     BOOLEAN       NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id               BIGINT AUTO_INCREMENT PRIMARY KEY,
+    subscription_id  INT          NOT NULL,
+    event_id         VARCHAR(64)  NOT NULL,
+    event_type       VARCHAR(64)  NOT NULL,
+    payload          TEXT         NOT NULL,
+    -- pending → delivered, atau pending → dead setelah percobaan habis (dead-letter).
+    status           VARCHAR(16)  NOT NULL DEFAULT 'pending',
+    attempts         INT          NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP    NOT NULL,
+    -- claim_token menandai worker yang sedang mengirim; next_attempt_at sekaligus menjadi batas lease-nya.
+    claim_token      VARCHAR(32)  NULL,
+    last_status_code INT          NULL,
+    last_error       VARCHAR(512) NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at     TIMESTAMP    NULL,
+
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+
+    -- MENGAPA index (status, next_attempt_at)?
+    -- Worker terus mencari pengiriman yang jatuh tempo: WHERE status = 'pending' AND next_attempt_at <= now
+    INDEX idx_due (status, next_attempt_at),
+    -- Delivery log per subscription, terbaru di atas.
+    INDEX idx_subscription (subscription_id, id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,12 @@
+-- Kolom hanya dihapus jika masih ada, sehingga rollback aman diulang (lihat catatan di script up).
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'loans'
+       AND COLUMN_NAME = 'overdue_notified_at') > 0,
+    'ALTER TABLE loans DROP COLUMN overdue_notified_at',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
@@ -0,0 +1,17 @@
+-- Waktu event loan.overdue dikirim untuk pinjaman ini.
+-- MENGAPA kolom di loans?
+-- Scan overdue berjalan berkala di setiap instance API; UPDATE ... WHERE overdue_notified_at IS NULL
+-- memastikan setiap pinjaman hanya memicu satu event, meskipun beberapa instance memindai bersamaan.
+-- MySQL tidak punya ADD COLUMN IF NOT EXISTS: kolom hanya ditambahkan jika belum ada di information_schema,
+-- sehingga script aman diulang jika proses mati sebelum versinya tercatat di schema_migrations.
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'loans'
+       AND COLUMN_NAME = 'overdue_notified_at') = 0,
+    'ALTER TABLE loans ADD COLUMN overdue_notified_at TIMESTAMP NULL AFTER returned_at',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
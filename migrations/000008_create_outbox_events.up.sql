@@ -0,0 +1,24 @@
+-- Transactional outbox: event domain ditulis di transaksi yang sama dengan perubahan datanya,
+-- lalu relay mempublikasikannya ke sink (webhook, log, broker).
+-- MENGAPA tidak publish langsung setelah COMMIT?
+-- Jika proses mati di antara COMMIT dan publish, event hilang padahal peminjaman sudah tersimpan.
+CREATE TABLE IF NOT EXISTS outbox_events
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id        VARCHAR(64)  NOT NULL,
+    event_type      VARCHAR(64)  NOT NULL,
+    -- Entitas sumber event, misalnya "loan:12"; event dengan aggregate sama dipublikasikan berurutan (id).
+    aggregate       VARCHAR(64)  NOT NULL,
+    -- Event lengkap dalam JSON, persis seperti yang diterima sink.
+    payload         TEXT         NOT NULL,
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP    NOT NULL,
+    last_error      VARCHAR(512) NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    published_at    TIMESTAMP    NULL,
+
+    UNIQUE KEY uq_event_id (event_id),
+    -- Relay membaca event yang belum dipublikasikan sesuai urutan tulis: WHERE published_at IS NULL ORDER BY id
+    INDEX idx_unpublished (published_at, id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
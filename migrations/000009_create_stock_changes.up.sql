@@ -0,0 +1,23 @@
+-- Log perubahan stok untuk stream SSE /books/stream. Ditulis di transaksi yang sama dengan UPDATE books,
+-- sehingga hanya perubahan yang benar-benar commit yang terlihat.
+-- MENGAPA tabel, bukan hanya notifikasi in-process?
+-- - Instance API lain ikut melihat perubahan dengan polling tabel ini
+-- - id menjadi ID event SSE: client yang terputus melanjutkan stream dari Last-Event-ID tanpa kehilangan perubahan
+CREATE TABLE IF NOT EXISTS stock_changes
+(
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    book_id    INT       NOT NULL,
+    -- Stok setelah perubahan (nilai absolut), sehingga client yang melewatkan event tetap mendapat nilai benar
+    -- pada event berikutnya.
+    stock      INT       NOT NULL,
+    delta      INT       NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+
+    -- Replay stream satu buku: WHERE book_id = ? AND id > ?
+    INDEX idx_book (book_id, id),
+    -- Pembersihan oleh libctl maintenance run prune-stock-changes
+    INDEX idx_created_at (created_at)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
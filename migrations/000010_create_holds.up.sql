@@ -0,0 +1,23 @@
+-- Reservasi buku yang stoknya habis. Antrean satu buku diurutkan berdasarkan id (siapa yang lebih dulu mengantre).
+-- MENGAPA status disimpan, bukan baris dihapus saat dipinjam/dibatalkan?
+-- - Riwayat reservasi tetap bisa dilihat member di dashboard (GraphQL Member.holds)
+-- - Posisi antrean hanya menghitung baris berstatus waiting, sehingga baris lama tidak mengganggu
+CREATE TABLE IF NOT EXISTS holds
+(
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    member_id  INT         NOT NULL,
+    book_id    INT         NOT NULL,
+    -- waiting: masih mengantre; fulfilled: member sudah meminjam bukunya; cancelled: dibatalkan
+    status     VARCHAR(16) NOT NULL DEFAULT 'waiting',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    closed_at  TIMESTAMP NULL,
+
+    FOREIGN KEY (member_id) REFERENCES members (id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+
+    -- Posisi antrean satu buku: WHERE book_id = ? AND status = 'waiting' AND id < ?
+    INDEX idx_book_queue (book_id, status, id),
+    -- Reservasi member untuk satu buku
+    INDEX idx_member_book (member_id, book_id, status)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
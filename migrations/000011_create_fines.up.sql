@@ -0,0 +1,24 @@
+-- Denda keterlambatan per pinjaman. amount disimpan sebagai nominal tetap, bukan dihitung ulang dari tarif,
+-- sehingga perubahan tarif tidak mengubah denda yang sudah tercatat.
+CREATE TABLE IF NOT EXISTS fines
+(
+    id           INT AUTO_INCREMENT PRIMARY KEY,
+    loan_id      INT       NOT NULL,
+    member_id    INT       NOT NULL,
+    book_id      INT       NOT NULL,
+    days_overdue INT       NOT NULL,
+    -- Nominal dalam satuan mata uang terkecil (rupiah)
+    amount       INT       NOT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    paid_at      TIMESTAMP NULL,
+
+    FOREIGN KEY (loan_id) REFERENCES loans (id) ON DELETE CASCADE,
+    FOREIGN KEY (member_id) REFERENCES members (id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+
+    -- Satu pinjaman paling banyak satu denda
+    UNIQUE KEY uq_loan (loan_id),
+    -- Denda member (GraphQL Member.fines)
+    INDEX idx_member (member_id, id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
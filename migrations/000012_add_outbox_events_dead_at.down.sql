@@ -0,0 +1,24 @@
+-- Index dan kolom hanya dihapus jika masih ada, sehingga rollback aman diulang (lihat catatan di script up).
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'outbox_events'
+       AND INDEX_NAME = 'idx_aggregate') > 0,
+    'ALTER TABLE outbox_events DROP INDEX idx_aggregate',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
+
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'outbox_events'
+       AND COLUMN_NAME = 'dead_at') > 0,
+    'ALTER TABLE outbox_events DROP COLUMN dead_at',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
@@ -0,0 +1,29 @@
+-- Diisi setelah outbox.max_attempts percobaan gagal; event berhenti dicoba dan tidak lagi menahan aggregate-nya.
+-- MENGAPA migration baru, bukan mengubah 000008?
+-- Database yang sudah menerapkan 000008 tidak akan menjalankannya lagi, sehingga kolom dan index ini
+-- tidak pernah sampai ke sana. Kolom dan index hanya ditambahkan jika belum ada di information_schema,
+-- sehingga script aman diulang jika proses mati sebelum versinya tercatat di schema_migrations.
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'outbox_events'
+       AND COLUMN_NAME = 'dead_at') = 0,
+    'ALTER TABLE outbox_events ADD COLUMN dead_at TIMESTAMP NULL AFTER published_at',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
+
+-- Relay melewati aggregate yang sedang tertahan retry (NOT EXISTS event lebih awal dari aggregate yang sama)
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'outbox_events'
+       AND INDEX_NAME = 'idx_aggregate') = 0,
+    'ALTER TABLE outbox_events ADD INDEX idx_aggregate (aggregate, id)',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
@@ -0,0 +1,24 @@
+-- Index dan kolom hanya dihapus jika masih ada, sehingga rollback aman diulang (lihat catatan di script up).
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'webhook_deliveries'
+       AND INDEX_NAME = 'idx_ordering') > 0,
+    'ALTER TABLE webhook_deliveries DROP INDEX idx_ordering',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
+
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'webhook_deliveries'
+       AND COLUMN_NAME = 'aggregate') > 0,
+    'ALTER TABLE webhook_deliveries DROP COLUMN aggregate',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
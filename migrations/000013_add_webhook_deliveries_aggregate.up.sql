@@ -0,0 +1,27 @@
+-- Entitas sumber event (misalnya "loan:12"); delivery satu subscription dengan aggregate sama dikirim berurutan.
+-- Seperti 000012, kolom dan index ditambahkan di migration baru (bukan di 000006) dan dijaga information_schema
+-- agar sampai ke database yang sudah menerapkan 000006 dan aman diulang.
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'webhook_deliveries'
+       AND COLUMN_NAME = 'aggregate') = 0,
+    'ALTER TABLE webhook_deliveries ADD COLUMN aggregate VARCHAR(64) NOT NULL DEFAULT '''' AFTER event_type',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
+
+-- Worker mencari delivery lebih awal yang masih pending untuk subscription & aggregate yang sama.
+SET @ddl = IF(
+    (SELECT COUNT(*)
+     FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE()
+       AND TABLE_NAME = 'webhook_deliveries'
+       AND INDEX_NAME = 'idx_ordering') = 0,
+    'ALTER TABLE webhook_deliveries ADD INDEX idx_ordering (subscription_id, aggregate, id)',
+    'SELECT 1');
+PREPARE migration_ddl FROM @ddl;
+EXECUTE migration_ddl;
+DEALLOCATE PREPARE migration_ddl;
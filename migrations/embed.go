@@ -0,0 +1,18 @@
+// Package migrations menyimpan file SQL schema & fixture yang di-embed ke dalam binary.
+package migrations
+
+import "embed"
+
+// Schema berisi migration bernomor dengan format <version>_<name>.up.sql / .down.sql.
+//
+// MENGAPA di-embed?
+// - Binary membawa schema yang sesuai dengan versinya sendiri, tidak tergantung file di disk
+// - Tidak perlu lagi mengandalkan docker-entrypoint MySQL yang hanya jalan saat volume pertama kali dibuat
+//
+//go:embed *.sql
+var Schema embed.FS
+
+// Fixtures berisi seed data opsional, dipisahkan dari schema agar tidak ikut jalan di production.
+//
+//go:embed fixtures/*.sql
+var Fixtures embed.FS
@@ -0,0 +1,30 @@
+-- Fixture data untuk development & testing.
+-- MENGAPA INSERT IGNORE dengan ID eksplisit?
+-- - Fixture bisa dijalankan berulang kali (misalnya setiap container start) tanpa menduplikasi data
+-- - Baris yang sudah ada (berdasarkan primary key / unique email) dilewati begitu saja
+
+-- Seed Data: Books
+-- total_copies sudah memperhitungkan sample loan aktif di bawah (book 2 & 3 masing-masing 1 dipinjam)
+INSERT IGNORE INTO books (id, title, author, total_copies, stock)
+VALUES (1, 'Clean Code', 'Robert C. Martin', 5, 5),
+       (2, 'The Pragmatic Programmer', 'Andrew Hunt', 4, 3),
+       (3, 'Design Patterns', 'Gang of Four', 3, 2),
+       (4, 'Refactoring', 'Martin Fowler', 4, 4),
+       (5, 'Head First Design Patterns', 'Eric Freeman', 1, 1),
+       (6, 'Code Complete', 'Steve McConnell', 6, 6),
+       (7, 'The Clean Coder', 'Robert C. Martin', 3, 3),
+       (8, 'Working Effectively with Legacy Code', 'Michael Feathers', 2, 2);
+
+-- Seed Data: Members
+INSERT IGNORE INTO members (id, name, email)
+VALUES (1, 'John Doe', 'john@example.com'),
+       (2, 'Jane Smith', 'jane@example.com'),
+       (3, 'Bob Johnson', 'bob@example.com'),
+       (4, 'Alice Williams', 'alice@example.com'),
+       (5, 'Charlie Brown', 'charlie@example.com');
+
+-- Seed Data: Sample Loans (untuk testing history)
+INSERT IGNORE INTO loans (id, member_id, book_id, borrowed_at, returned_at)
+VALUES (1, 1, 1, DATE_SUB(NOW(), INTERVAL 10 DAY), DATE_SUB(NOW(), INTERVAL 3 DAY)),
+       (2, 2, 2, DATE_SUB(NOW(), INTERVAL 7 DAY), NULL),
+       (3, 3, 3, DATE_SUB(NOW(), INTERVAL 5 DAY), NULL);